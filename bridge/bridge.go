@@ -0,0 +1,70 @@
+/*
+Package bridge converts matrices between the packages
+github.com/gonutz/d3dmath/row_major/d3dmath and
+github.com/gonutz/d3dmath/column_major/d3dmath.
+
+Both packages use row vectors, so a matrix that transforms a vector in one
+package transforms it the same way after conversion to the other package. Only
+the order of the elements in memory changes. Use the row-major matrices for
+shader constants declared row_major in HLSL and the column-major matrices for
+the default column_major constants.
+
+Vectors, quaternions and planes have the same layout in both packages and
+convert with a simple type conversion, e.g. column.Vec3(v).
+*/
+package bridge
+
+import (
+	column "github.com/gonutz/d3dmath/column_major/d3dmath"
+	row "github.com/gonutz/d3dmath/row_major/d3dmath"
+)
+
+// ToRowMajor2 converts the column-major m to a row-major matrix.
+func ToRowMajor2(m column.Mat2) row.Mat2 {
+	return row.Mat2(m.Transposed())
+}
+
+// ToColumnMajor2 converts the row-major m to a column-major matrix.
+func ToColumnMajor2(m row.Mat2) column.Mat2 {
+	return column.Mat2(m.Transposed())
+}
+
+// ToRowMajor3 converts the column-major m to a row-major matrix.
+func ToRowMajor3(m column.Mat3) row.Mat3 {
+	return row.Mat3(m.Transposed())
+}
+
+// ToColumnMajor3 converts the row-major m to a column-major matrix.
+func ToColumnMajor3(m row.Mat3) column.Mat3 {
+	return column.Mat3(m.Transposed())
+}
+
+// ToRowMajor4 converts the column-major m to a row-major matrix.
+func ToRowMajor4(m column.Mat4) row.Mat4 {
+	return row.Mat4(m.Transposed())
+}
+
+// ToColumnMajor4 converts the row-major m to a column-major matrix.
+func ToColumnMajor4(m row.Mat4) column.Mat4 {
+	return column.Mat4(m.Transposed())
+}
+
+// ToRowMajor2x3 converts the column-major m to a row-major matrix. Mat2x3 is
+// not square, the column-major layout stores three columns of two elements
+// and the row-major layout stores two rows of three elements.
+func ToRowMajor2x3(m column.Mat2x3) row.Mat2x3 {
+	return row.Mat2x3{
+		m[0], m[2], m[4],
+		m[1], m[3], m[5],
+	}
+}
+
+// ToColumnMajor2x3 converts the row-major m to a column-major matrix, see
+// ToRowMajor2x3.
+func ToColumnMajor2x3(m row.Mat2x3) column.Mat2x3 {
+	return column.Mat2x3{
+		m[0], m[3],
+		m[1], m[4],
+		m[2], m[5],
+	}
+}
@@ -0,0 +1,103 @@
+package bridge
+
+import (
+	"testing"
+
+	column "github.com/gonutz/d3dmath/column_major/d3dmath"
+	row "github.com/gonutz/d3dmath/row_major/d3dmath"
+)
+
+func TestMat4TransformsLikeInBothLayouts(t *testing.T) {
+	r := row.Mul4(
+		row.Scale(2, 3, 4),
+		row.RotateLeftHandAbout(row.Vec3{1, 2, 3}, 0.3),
+		row.Translate(5, 6, 7),
+		row.PerspectiveFovLH(1, 1.5, 0.5, 100),
+	)
+	c := ToColumnMajor4(r)
+	for _, v := range []row.Vec4{{1, 0, 0, 1}, {0, 1, 0, 0}, {2, -3, 4, 1}} {
+		want := v.MulMat(r)
+		have := column.Vec4(v).MulMat(c)
+		checkFloats(t, have[:], want[:]...)
+	}
+	if ToRowMajor4(c) != r {
+		t.Error("round trip changed the matrix")
+	}
+}
+
+func TestMat4ConstructorsAgree(t *testing.T) {
+	c := column.Mul4(
+		column.RotateRightHandX(0.1),
+		column.Translate(1, 2, 3),
+		column.LookAtLH(column.Vec3{1, 2, 3}, column.Vec3{}, column.Vec3{0, 1, 0}),
+	)
+	r := row.Mul4(
+		row.RotateRightHandX(0.1),
+		row.Translate(1, 2, 3),
+		row.LookAtLH(row.Vec3{1, 2, 3}, row.Vec3{}, row.Vec3{0, 1, 0}),
+	)
+	have := ToRowMajor4(c)
+	checkFloats(t, have[:], r[:]...)
+}
+
+func TestMat3TransformsLikeInBothLayouts(t *testing.T) {
+	r := row.QuatLeftHandAbout(row.Vec3{3, 1, 2}, 0.2).ToMat3()
+	r[1] += 2 // Add a shear, rotations are too symmetric.
+	c := ToColumnMajor3(r)
+	v := row.Vec3{1, 2, 3}
+	want := v.MulMat(r)
+	have := column.Vec3(v).MulMat(c)
+	checkFloats(t, have[:], want[:]...)
+	if ToRowMajor3(c) != r {
+		t.Error("round trip changed the matrix")
+	}
+}
+
+func TestMat2TransformsLikeInBothLayouts(t *testing.T) {
+	r := row.Mat2{1, 2, 3, 4}
+	c := ToColumnMajor2(r)
+	v := row.Vec2{5, 6}
+	want := v.MulMat(r)
+	have := column.Vec2(v).MulMat(c)
+	checkFloats(t, have[:], want[:]...)
+	if ToRowMajor2(c) != r {
+		t.Error("round trip changed the matrix")
+	}
+}
+
+func TestMat2x3TransformsLikeInBothLayouts(t *testing.T) {
+	r := row.Mul2x3(
+		row.Translate2D(5, 6),
+		row.RotateLeftHand2D(0.1),
+		row.Shear2D(0.5, 0),
+		row.Scale2D(2, 3),
+	)
+	c := ToColumnMajor2x3(r)
+	for _, v := range []row.Vec2{{0, 0}, {1, 0}, {0, 1}, {-2, 7}} {
+		want := v.TransformCoord2x3(r)
+		have := column.Vec2(v).TransformCoord2x3(c)
+		checkFloats(t, have[:], want[:]...)
+	}
+	if ToRowMajor2x3(c) != r {
+		t.Error("round trip changed the matrix")
+	}
+
+	have := ToRowMajor2x3(column.Translate2D(1, 2))
+	want := row.Translate2D(1, 2)
+	checkFloats(t, have[:], want[:]...)
+}
+
+func checkFloats(t *testing.T, have []float32, want ...float32) {
+	t.Helper()
+	eq := len(have) == len(want)
+	if eq {
+		for i := range have {
+			if row.ULPDistance(have[i], want[i]) > 4 {
+				eq = false
+			}
+		}
+	}
+	if !eq {
+		t.Errorf("floats differ, have\n%v\nbut want\n%v", have, want)
+	}
+}
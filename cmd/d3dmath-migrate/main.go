@@ -0,0 +1,133 @@
+/*
+Command d3dmath-migrate rewrites Go code that uses the legacy package
+github.com/gonutz/d3dmath to use github.com/gonutz/d3dmath/row_major/d3dmath
+instead.
+
+Usage:
+
+	d3dmath-migrate [-w] [-l] [path ...]
+
+The paths are Go files or directories, which are searched recursively. Without
+flags, the rewritten versions of all affected files are printed to standard
+output. With -w they are written back to the files and with -l only their names
+are printed.
+
+The import path is changed and the rotation functions RotateX, RotateY, RotateZ
+and RotateAbout are replaced by their left-handed counterparts, with the angle
+converted from radians to turns, e.g.
+
+	d3dmath.RotateX(angle)
+
+becomes
+
+	d3dmath.RotateLeftHandX(angle * d3dmath.RadToTurns)
+
+All other functions and types have the same names and semantics in both
+packages. Uses that cannot be rewritten automatically are reported on standard
+error.
+*/
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	write = flag.Bool("w", false, "write results to the source files instead of standard output")
+	list  = flag.Bool("l", false, "list the files that would be changed")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: d3dmath-migrate [-w] [-l] [path ...]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	paths := flag.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	failed := false
+	for _, root := range paths {
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				name := info.Name()
+				if path != root && (strings.HasPrefix(name, ".") ||
+					name == "vendor" || name == "testdata") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !strings.HasSuffix(path, ".go") {
+				return nil
+			}
+			if err := processFile(path, info.Mode()); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				failed = true
+			}
+			return nil
+		})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func processFile(path string, mode os.FileMode) error {
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	out, changed, warnings, err := rewrite(path, src)
+	for _, w := range warnings {
+		fmt.Fprintln(os.Stderr, w)
+	}
+	if err != nil || !changed {
+		return err
+	}
+	if *list {
+		fmt.Println(path)
+	}
+	if *write {
+		return ioutil.WriteFile(path, out, mode.Perm())
+	}
+	if !*list {
+		_, err = os.Stdout.Write(out)
+	}
+	return err
+}
+
+// rewrite migrates the Go source src and returns the formatted result.
+func rewrite(filename string, src []byte) (out []byte, changed bool, warnings []string, err error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, false, nil, err
+	}
+	changed, warnings = migrate(fset, file)
+	if !changed {
+		return src, false, warnings, nil
+	}
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, false, warnings, err
+	}
+	return buf.Bytes(), true, warnings, nil
+}
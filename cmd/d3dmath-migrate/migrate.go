@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strconv"
+)
+
+const (
+	legacyPath = "github.com/gonutz/d3dmath"
+	newPath    = "github.com/gonutz/d3dmath/row_major/d3dmath"
+)
+
+// rotations maps the legacy rotation functions, which take radians, to their
+// replacements in the row-major package, which take turns. All legacy
+// rotations are left-handed.
+var rotations = map[string]string{
+	"RotateX":     "RotateLeftHandX",
+	"RotateY":     "RotateLeftHandY",
+	"RotateZ":     "RotateLeftHandZ",
+	"RotateAbout": "RotateLeftHandAbout",
+}
+
+// migrate rewrites file to use the row-major package instead of the legacy
+// top-level package. All other functions and types of the legacy package have
+// the same names and semantics in the row-major package, only the rotations
+// need to be renamed and have their angles converted from radians to turns.
+//
+// changed is true if file was modified. The returned warnings describe uses
+// that cannot be rewritten automatically, e.g. a rotation function that is
+// used as a value instead of being called.
+func migrate(fset *token.FileSet, file *ast.File) (changed bool, warnings []string) {
+	var spec *ast.ImportSpec
+	for _, s := range file.Imports {
+		if path, err := strconv.Unquote(s.Path.Value); err == nil && path == legacyPath {
+			spec = s
+		}
+	}
+	if spec == nil {
+		return false, nil
+	}
+	spec.Path.Value = strconv.Quote(newPath)
+	changed = true
+
+	name := "d3dmath"
+	if spec.Name != nil {
+		name = spec.Name.Name
+	}
+	warn := func(pos token.Pos, format string, a ...interface{}) {
+		warnings = append(warnings, fmt.Sprintf("%s: %s", fset.Position(pos), fmt.Sprintf(format, a...)))
+	}
+	switch name {
+	case "_":
+		return
+	case ".":
+		warn(spec.Pos(), "dot import, rotation functions are not rewritten")
+		return
+	}
+
+	// isRotation reports whether e is a legacy rotation function, e.g.
+	// d3dmath.RotateX.
+	isRotation := func(e ast.Expr) (*ast.SelectorExpr, bool) {
+		sel, ok := e.(*ast.SelectorExpr)
+		if !ok {
+			return nil, false
+		}
+		pkg, ok := sel.X.(*ast.Ident)
+		if !ok || pkg.Name != name {
+			return nil, false
+		}
+		_, ok = rotations[sel.Sel.Name]
+		return sel, ok
+	}
+
+	called := make(map[*ast.SelectorExpr]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := isRotation(call.Fun)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+		called[sel] = true
+		sel.Sel = ast.NewIdent(rotations[sel.Sel.Name])
+		last := len(call.Args) - 1
+		call.Args[last] = radiansToTurns(call.Args[last], name)
+		return true
+	})
+	ast.Inspect(file, func(n ast.Node) bool {
+		if e, ok := n.(ast.Expr); ok {
+			if sel, ok := isRotation(e); ok && !called[sel] {
+				warn(sel.Pos(), "%s.%s takes radians but %s.%s takes turns, rewrite it by hand",
+					name, sel.Sel.Name, name, rotations[sel.Sel.Name])
+			}
+		}
+		return true
+	})
+	return
+}
+
+// radiansToTurns returns the expression angle * pkg.RadToTurns.
+func radiansToTurns(angle ast.Expr, pkg string) ast.Expr {
+	if b, ok := angle.(*ast.BinaryExpr); ok && b.Op.Precedence() < token.MUL.Precedence() {
+		angle = &ast.ParenExpr{X: angle}
+	}
+	return &ast.BinaryExpr{
+		X:  angle,
+		Op: token.MUL,
+		Y: &ast.SelectorExpr{
+			X:   ast.NewIdent(pkg),
+			Sel: ast.NewIdent("RadToTurns"),
+		},
+	}
+}
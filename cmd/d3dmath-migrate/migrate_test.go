@@ -0,0 +1,137 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	legacy "github.com/gonutz/d3dmath"
+	"github.com/gonutz/d3dmath/row_major/d3dmath"
+)
+
+func TestRewriteRotations(t *testing.T) {
+	checkRewrite(t, `package p
+
+import (
+	"math"
+
+	"github.com/gonutz/d3dmath"
+)
+
+func f(a float32, axis d3dmath.Vec3) d3dmath.Mat4 {
+	// Rotations take turns after the migration.
+	return d3dmath.Mul4(
+		d3dmath.RotateX(a),
+		d3dmath.RotateY(math.Pi/2),
+		d3dmath.RotateZ(a+1),
+		d3dmath.RotateAbout(axis, -a),
+		d3dmath.Translate(1, 2, 3),
+	)
+}
+`, `package p
+
+import (
+	"math"
+
+	"github.com/gonutz/d3dmath/row_major/d3dmath"
+)
+
+func f(a float32, axis d3dmath.Vec3) d3dmath.Mat4 {
+	// Rotations take turns after the migration.
+	return d3dmath.Mul4(
+		d3dmath.RotateLeftHandX(a*d3dmath.RadToTurns),
+		d3dmath.RotateLeftHandY(math.Pi/2*d3dmath.RadToTurns),
+		d3dmath.RotateLeftHandZ((a+1)*d3dmath.RadToTurns),
+		d3dmath.RotateLeftHandAbout(axis, -a*d3dmath.RadToTurns),
+		d3dmath.Translate(1, 2, 3),
+	)
+}
+`)
+}
+
+func TestRewriteRenamedImport(t *testing.T) {
+	checkRewrite(t, `package p
+
+import dm "github.com/gonutz/d3dmath"
+
+var m = dm.RotateZ(1).Mul(dm.ScaleV(dm.Vec3{1, 2, 3}))
+`, `package p
+
+import dm "github.com/gonutz/d3dmath/row_major/d3dmath"
+
+var m = dm.RotateLeftHandZ(1 * dm.RadToTurns).Mul(dm.ScaleV(dm.Vec3{1, 2, 3}))
+`)
+}
+
+func TestOtherFilesAreNotChanged(t *testing.T) {
+	src := `package p
+
+import "github.com/gonutz/d3dmath/row_major/d3dmath"
+
+var m = d3dmath.RotateX(1)
+`
+	out, changed, warnings, err := rewrite("p.go", []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed || string(out) != src || len(warnings) != 0 {
+		t.Errorf("file was changed to\n%s\nwith warnings %v", out, warnings)
+	}
+}
+
+func TestRotationValuesAreReported(t *testing.T) {
+	_, changed, warnings, err := rewrite("p.go", []byte(`package p
+
+import "github.com/gonutz/d3dmath"
+
+var rotate = d3dmath.RotateY
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Error("import was not rewritten")
+	}
+	if len(warnings) != 1 ||
+		!strings.HasPrefix(warnings[0], "p.go:5:14: d3dmath.RotateY takes radians") {
+		t.Errorf("unexpected warnings %q", warnings)
+	}
+}
+
+func TestRewrittenRotationsAreEquivalent(t *testing.T) {
+	// The rewritten calls must produce the same matrices as the legacy ones.
+	const a = 0.7
+	axis := legacy.Vec3{1, 2, 3}
+	check := func(name string, have d3dmath.Mat4, want legacy.Mat4) {
+		for i := range have {
+			if math.Abs(float64(have[i]-want[i])) > 1e-6 {
+				t.Errorf("%s differs, have\n%v\nbut want\n%v", name, have, want)
+				return
+			}
+		}
+	}
+	check("RotateX", d3dmath.RotateLeftHandX(a*d3dmath.RadToTurns), legacy.RotateX(a))
+	check("RotateY", d3dmath.RotateLeftHandY(a*d3dmath.RadToTurns), legacy.RotateY(a))
+	check("RotateZ", d3dmath.RotateLeftHandZ(a*d3dmath.RadToTurns), legacy.RotateZ(a))
+	check("RotateAbout",
+		d3dmath.RotateLeftHandAbout(d3dmath.Vec3(axis), a*d3dmath.RadToTurns),
+		legacy.RotateAbout(axis, a),
+	)
+}
+
+func checkRewrite(t *testing.T, src, want string) {
+	t.Helper()
+	out, changed, warnings, err := rewrite("p.go", []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Error("file was not changed")
+	}
+	if len(warnings) != 0 {
+		t.Errorf("unexpected warnings %v", warnings)
+	}
+	if string(out) != want {
+		t.Errorf("have\n%s\nbut want\n%s", out, want)
+	}
+}
@@ -0,0 +1,5 @@
+/*
+Package d3dmath provides vector and matrix functions for Direct3D. Vectors are
+row vectors and matrices are stored in column-major order.
+*/
+package d3dmath
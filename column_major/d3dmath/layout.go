@@ -0,0 +1,22 @@
+package d3dmath
+
+import "github.com/gonutz/d3dmath/internal/simd"
+
+// This file holds the code that depends on the memory layout of the matrices.
+// All other files of this package are generated from package
+// row_major/d3dmath, see internal/gen.
+
+// mulSIMD returns the product of m * n, computed with SIMD instructions.
+func mulSIMD(m, n *Mat4) Mat4 {
+	var product Mat4
+	// The columns of the product are the columns of n transformed by m.
+	simd.Transform(product[:], 4, n[:], 4, 4, (*[16]float32)(m))
+	return product
+}
+
+// rowMajor returns the elements of m in row-major order, which package simd
+// expects.
+func (m *Mat4) rowMajor() *[16]float32 {
+	rows := m.Transposed()
+	return (*[16]float32)(&rows)
+}
@@ -0,0 +1,31 @@
+/*
+Package d3dmath is the API of version 1.0.0 of this module, kept for backwards
+compatibility. Its functions delegate to package
+github.com/gonutz/d3dmath/row_major/d3dmath, which uses the same memory layout,
+so all types convert to and from those of that package without copying. Only
+the rotations keep their own radians based code, which gives the exact results
+of version 1.0.0.
+
+Unlike the sub-packages, this package takes rotation angles in radians and its
+rotations are left-handed. The tool github.com/gonutz/d3dmath/cmd/d3dmath-migrate
+rewrites code that uses this package to use the row-major sub-package instead.
+
+Deprecated: Use github.com/gonutz/d3dmath/row_major/d3dmath or
+github.com/gonutz/d3dmath/column_major/d3dmath.
+*/
+package d3dmath
+
+import rowmajor "github.com/gonutz/d3dmath/row_major/d3dmath"
+
+// These factors can be used to convert between turns (as used for the rotation
+// functions in the sub-packages), radians and degrees.
+// For example, to convert a half rotation in degrees to radians, you can say
+// 180 * DegToRad.
+const (
+	TurnsToRad = rowmajor.TurnsToRad
+	RadToTurns = rowmajor.RadToTurns
+	RadToDeg   = rowmajor.RadToDeg
+	DegToRad   = rowmajor.DegToRad
+	TurnsToDeg = rowmajor.TurnsToDeg
+	DegToTurns = rowmajor.DegToTurns
+)
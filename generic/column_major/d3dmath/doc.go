@@ -0,0 +1,14 @@
+/*
+Package d3dmath provides vector and matrix functions for Direct3D, generic over
+the element type. Vectors are row vectors and matrices are stored in
+column-major order.
+
+The vector, matrix, quaternion and plane types are arrays of T, so they convert
+to the types of package github.com/gonutz/d3dmath/column_major/d3dmath and
+d3dmath64 without copying or changing the memory layout. With this package
+imported as generic:
+
+	m := d3dmath.Mat4(generic.Translate[float32](1, 2, 3))
+	m64 := d3dmath64.Mat4(generic.Translate[float64](1, 2, 3))
+*/
+package d3dmath
@@ -0,0 +1,41 @@
+package d3dmath
+
+import (
+	"math"
+	"unsafe"
+)
+
+// This file holds the code that depends on the float type. It replaces
+// float32.go of the non-generic package, see internal/gen.
+
+// Float is the set of element types that vectors and matrices can have.
+type Float interface {
+	~float32 | ~float64
+}
+
+// ulpKey maps the bits of f to an integer that is monotonic in f, so the
+// difference of two keys is their distance in ULPs.
+func ulpKey[T Float](f T) uint64 {
+	if unsafe.Sizeof(f) == 4 {
+		const signBit = 1 << 31
+		bits := uint64(math.Float32bits(float32(f)))
+		if bits&signBit != 0 {
+			return signBit - (bits &^ signBit)
+		}
+		return signBit + bits
+	}
+	const signBit = 1 << 63
+	bits := math.Float64bits(float64(f))
+	if bits&signBit != 0 {
+		return signBit - (bits &^ signBit)
+	}
+	return signBit + bits
+}
+
+// epsilon returns the difference between 1 and the next larger T.
+func epsilon[T Float]() T {
+	if unsafe.Sizeof(T(0)) == 4 {
+		return 1.0 / (1 << 23)
+	}
+	return 1.0 / (1 << 52)
+}
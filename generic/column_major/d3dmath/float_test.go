@@ -0,0 +1,25 @@
+package d3dmath
+
+import (
+	"math"
+	"unsafe"
+)
+
+// This file replaces float32_test.go of the non-generic package, see
+// internal/gen.
+
+// nearTolerance returns the absolute tolerance of checkFloatsNear.
+func nearTolerance[T Float]() T {
+	if unsafe.Sizeof(T(0)) == 4 {
+		return 1e-5
+	}
+	return 1e-9
+}
+
+// nextafter returns the next T after x towards y.
+func nextafter[T Float](x, y T) T {
+	if unsafe.Sizeof(x) == 4 {
+		return T(math.Nextafter32(float32(x), float32(y)))
+	}
+	return T(math.Nextafter(float64(x), float64(y)))
+}
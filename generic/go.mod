@@ -0,0 +1,3 @@
+module github.com/gonutz/d3dmath/generic
+
+go 1.18
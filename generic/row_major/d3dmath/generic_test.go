@@ -0,0 +1,126 @@
+package d3dmath
+
+import (
+	"math"
+	"testing"
+	"unsafe"
+)
+
+// These mirror the array types of the non-generic packages.
+type (
+	mat4f32 [16]float32
+	mat4f64 [16]float64
+	vec3f32 [3]float32
+)
+
+func TestFloat32AndFloat64Instantiations(t *testing.T) {
+	v32 := Vec3[float32]{1, 2, 3}.Homogeneous().MulMat(Translate[float32](4, 5, 6))
+	p32 := v32.ByW()
+	checkFloats(t, p32[:], 5, 7, 9)
+
+	v64 := Vec3[float64]{1, 2, 3}.Homogeneous().MulMat(Translate(4.0, 5, 6))
+	p64 := v64.ByW()
+	checkFloats(t, p64[:], 5, 7, 9)
+
+	// float64 keeps offsets that float32 rounds away.
+	far := Vec3[float64]{1e8 + 0.25, 0, 0}.Homogeneous().MulMat(Translate(-1e8, 0, 0.0))
+	near := far.DropW()
+	checkFloats(t, near[:], 0.25, 0, 0)
+}
+
+func TestConversionToArrayTypes(t *testing.T) {
+	m := Mul4(Scale[float32](2, 3, 4), Translate[float32](1, 2, 3))
+	if unsafe.Sizeof(m) != unsafe.Sizeof(mat4f32{}) {
+		t.Error("Mat4[float32] has a different size than [16]float32")
+	}
+	plain := mat4f32(m)
+	if Mat4[float32](plain) != m {
+		t.Error("conversion changed the matrix")
+	}
+	checkFloats(t, plain[:],
+		2, 0, 0, 0,
+		0, 3, 0, 0,
+		0, 0, 4, 0,
+		1, 2, 3, 1,
+	)
+
+	m64 := Mat4[float64](Identity4[float64]())
+	if mat4f64(m64) != (mat4f64{0: 1, 5: 1, 10: 1, 15: 1}) {
+		t.Errorf("unexpected identity %v", m64)
+	}
+
+	v := vec3f32(Vec3[float32]{1, 2, 3}.Cross(Vec3[float32]{4, 5, 6}))
+	if v != (vec3f32{-3, 6, -3}) {
+		t.Errorf("unexpected cross product %v", v)
+	}
+}
+
+func TestGenericVectors(t *testing.T) {
+	v := Vec3[float64]{3, 4, 12}
+	checkFloats(t, []float64{v.Norm()}, 13)
+	n := v.Normalized()
+	checkFloatsNear(t, n[:], 3.0/13, 4.0/13, 12.0/13)
+	sum := AddVec2(Vec2[float32]{1, 2}, Vec2[float32]{3, 4}, Vec2[float32]{5, 6})
+	checkFloats(t, sum[:], 9, 12)
+	checkString(t, Vec4[float32]{1, 2, 3, 4}.String(), "(1.00 2.00 3.00 4.00)")
+}
+
+func TestGenericZeroVectors(t *testing.T) {
+	n := Vec3[float64]{}.Normalized()
+	checkFloats(t, n[:], 0, 0, 0)
+	v := Vec4[float32]{2, 3, 4, 0}.ByW()
+	checkFloats(t, v[:], 2, 3, 4)
+}
+
+func TestGenericInverse(t *testing.T) {
+	m := Mul4(
+		Scale[float64](2, 3, 4),
+		RotateRightHandAbout(Vec3[float64]{1, 2, 3}, 0.2),
+		Translate[float64](1, -2, 3),
+	)
+	inv, ok := m.Inverse()
+	if !ok {
+		t.Fatal("matrix should be invertible")
+	}
+	id := m.Mul(inv)
+	want := Identity4[float64]()
+	checkFloatsNear(t, id[:], want[:]...)
+
+	_, ok = Scale[float32](1, 0, 1).Inverse()
+	if ok {
+		t.Error("singular matrix was inverted")
+	}
+}
+
+func TestGenericQuaternion(t *testing.T) {
+	q := QuatLeftHandZ[float32](0.25)
+	v := q.Rotate(Vec3[float32]{1, 0, 0})
+	checkFloatsNear(t, v[:], 0, 1, 0)
+
+	m := q.ToMat4()
+	back := m.ToQuat()
+	checkFloatsNear(t, back[:], q[:]...)
+}
+
+func TestGenericProjection(t *testing.T) {
+	view := LookAtLH(Vec3[float64]{0, 0, -5}, Vec3[float64]{}, Vec3[float64]{0, 1, 0})
+	proj := PerspectiveFovLH(math.Pi/2, 1, 1, 10.0)
+	p := Vec3[float64]{0, 0, 5}.Homogeneous().MulMat(view.Mul(proj)).ByW()
+	checkFloatsNear(t, p[:], 0, 0, 1)
+}
+
+func TestGenericULPDistance(t *testing.T) {
+	// ULPs count representable values, which are denser for float64.
+	if d := ULPDistance[float32](1, 2); d != 1<<23 {
+		t.Errorf("float32 distance is %d", d)
+	}
+	if d := ULPDistance[float64](1, 2); d != 1<<52 {
+		t.Errorf("float64 distance is %d", d)
+	}
+	if d := ULPDistance(-1, math.Nextafter(-1, 0)); d != 1 {
+		t.Errorf("float64 neighbor distance is %d", d)
+	}
+	if !Translate[float32](1, 2, 3).IsAffine(0) {
+		t.Error("translation is not affine")
+	}
+}
@@ -0,0 +1,10 @@
+package d3dmath
+
+import "testing"
+
+// benchmarkSIMD runs f. The generic package has no SIMD code, the function
+// exists because the benchmarks are generated from the float32 package, which
+// runs them with and without SIMD.
+func benchmarkSIMD(b *testing.B, f func(b *testing.B)) {
+	b.Run("go", f)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+)
+
+func TestGeneratedFilesAreUpToDate(t *testing.T) {
+	root, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := generate(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range steps {
+		p, err := readPkg(root, s.to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for name, data := range p.files {
+			want, ok := files[s.to+"/"+name]
+			if isGenerated(data) && !ok {
+				t.Errorf("%s/%s is no longer generated", s.to, name)
+			}
+			if ok && !bytes.Equal(data, want) {
+				t.Errorf("%s/%s is out of date, run go generate", s.to, name)
+			}
+		}
+		for name := range files {
+			if path.Dir(name) == s.to {
+				if _, err := os.Stat(filepath.Join(root, filepath.FromSlash(name))); err != nil {
+					t.Errorf("%s is missing, run go generate", name)
+				}
+			}
+		}
+	}
+}
+
+func TestTransposed(t *testing.T) {
+	// The 2x3 matrix
+	//  0 1 2
+	//  3 4 5
+	// is stored as 0 3 1 4 2 5 in column-major order.
+	want := []int{0, 2, 4, 1, 3, 5}
+	for i, w := range want {
+		if have := transposed(i, 2, 3); have != w {
+			t.Errorf("element %d: have %d but want %d", i, have, w)
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"go/ast"
+	"go/format"
+	"go/types"
+	"strings"
+)
+
+// genericNames maps the float32 functions of package math to their generic
+// replacements in the hand-written files of the generic packages.
+var genericNames = map[string]string{
+	"Nextafter32": "nextafter[T]",
+}
+
+// toGeneric makes the package p generic over its float type. Every type that
+// contains float32 gets the type parameter T Float, which replaces float32,
+// and so does every function that uses these types.
+//
+// Tests and benchmarks become generic functions as well, with their names
+// starting in lower case. The new test or benchmark of the original name runs
+// them for float32 and float64.
+func (g *generator) toGeneric(j *job) (map[string][]byte, error) {
+	c, err := g.check(j.src, j.skipped)
+	if err != nil {
+		return nil, err
+	}
+	gen := genericObjects(c)
+	out := make(map[string][]byte)
+	for _, name := range j.src.names() {
+		f := c.asts[name]
+		var e edits
+		e.stripSIMD(c, name)
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.TypeSpec:
+				if gen[c.info.Defs[n.Name]] {
+					e.insert(c, n.Name.End(), "[T Float]")
+				}
+			case *ast.FuncDecl:
+				if gen[c.info.Defs[n.Name]] {
+					if kind := testKind(n); kind != "" {
+						e.runForFloats(c, n, kind)
+					}
+					e.insert(c, n.Name.End(), "[T Float]")
+				}
+			case *ast.SelectorExpr:
+				if to, ok := genericNames[n.Sel.Name]; ok && c.isPkg(n.X, "math") {
+					e.replace(c, n, to)
+				}
+			case *ast.CallExpr:
+				id, ok := n.Fun.(*ast.Ident)
+				if !ok {
+					break
+				}
+				if fn, isFunc := c.info.Uses[id].(*types.Func); isFunc && gen[fn] && !infersT(c, n, gen) {
+					e.insert(c, id.End(), "[T]")
+				}
+			case *ast.Ident:
+				obj := c.info.Uses[n]
+				if c.isUniverse(n, "float32") {
+					e.replace(c, n, "T")
+				} else if _, isType := obj.(*types.TypeName); isType && gen[obj] {
+					e.insert(c, n.End(), "[T]")
+				}
+			}
+			return true
+		})
+		// Generic functions that are not called need their type argument.
+		calls := make(map[*ast.Ident]bool)
+		ast.Inspect(f, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok {
+				if id, ok := call.Fun.(*ast.Ident); ok {
+					calls[id] = true
+				}
+			}
+			return true
+		})
+		ast.Inspect(f, func(n ast.Node) bool {
+			if id, ok := n.(*ast.Ident); ok && !calls[id] {
+				if _, isFunc := c.info.Uses[id].(*types.Func); isFunc && gen[c.info.Uses[id]] {
+					e.insert(c, id.End(), "[T]")
+				}
+			}
+			return true
+		})
+		e.replaceInComments(c, f, map[string]string{"float32s": "Ts"})
+
+		src, err := e.apply(j.src.files[name])
+		if err == nil {
+			src, err = removeUnusedImports(src)
+		}
+		if err == nil {
+			src, err = format.Source(src)
+		}
+		if err != nil {
+			return nil, err
+		}
+		out[name] = src
+	}
+	return out, nil
+}
+
+// genericObjects returns the package level types and functions of c that
+// become generic, i.e. that use float32 directly or through other generic
+// types and functions.
+func genericObjects(c *checkedPkg) map[types.Object]bool {
+	gen := make(map[types.Object]bool)
+	scope := c.types.Scope()
+	// mentions reports whether t contains float32 or a generic type.
+	var mentions func(t types.Type, seen map[types.Type]bool) bool
+	mentions = func(t types.Type, seen map[types.Type]bool) bool {
+		if seen[t] {
+			return false
+		}
+		seen[t] = true
+		switch t := t.(type) {
+		case *types.Basic:
+			return t.Kind() == types.Float32
+		case *types.Named:
+			if t.Obj().Pkg() != c.types {
+				return false
+			}
+			return gen[t.Obj()] || mentions(t.Underlying(), seen)
+		case *types.Array:
+			return mentions(t.Elem(), seen)
+		case *types.Slice:
+			return mentions(t.Elem(), seen)
+		case *types.Pointer:
+			return mentions(t.Elem(), seen)
+		case *types.Map:
+			return mentions(t.Key(), seen) || mentions(t.Elem(), seen)
+		case *types.Struct:
+			for i := 0; i < t.NumFields(); i++ {
+				if mentions(t.Field(i).Type(), seen) {
+					return true
+				}
+			}
+		case *types.Signature:
+			return mentions(t.Params(), seen) || mentions(t.Results(), seen)
+		case *types.Tuple:
+			for i := 0; i < t.Len(); i++ {
+				if mentions(t.At(i).Type(), seen) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	for _, name := range scope.Names() {
+		if obj, ok := scope.Lookup(name).(*types.TypeName); ok &&
+			mentions(obj.Type().Underlying(), make(map[types.Type]bool)) {
+			gen[obj] = true
+		}
+	}
+	// The functions of the skipped files, e.g. in float32.go, have no syntax
+	// here. Their generic versions are written by hand, with the float type in
+	// their signatures.
+	for _, name := range scope.Names() {
+		if obj, ok := scope.Lookup(name).(*types.Func); ok &&
+			mentions(obj.Type(), make(map[types.Type]bool)) {
+			gen[obj] = true
+		}
+	}
+
+	// Functions are generic if they use float32, a generic type or a
+	// generic function anywhere.
+	var funcs []*ast.FuncDecl
+	for _, f := range c.asts {
+		for _, decl := range f.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil {
+				funcs = append(funcs, fn)
+			}
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, fn := range funcs {
+			obj := c.info.Defs[fn.Name]
+			if gen[obj] {
+				continue
+			}
+			ast.Inspect(fn, func(n ast.Node) bool {
+				if id, ok := n.(*ast.Ident); ok && !gen[obj] {
+					used := c.info.Uses[id]
+					if c.isUniverse(id, "float32") || gen[used] {
+						gen[obj] = true
+						changed = true
+					} else if tv, ok := c.info.Types[id]; ok && tv.Type != nil &&
+						mentions(tv.Type, make(map[types.Type]bool)) {
+						gen[obj] = true
+						changed = true
+					}
+				}
+				return !gen[obj]
+			})
+		}
+	}
+	return gen
+}
+
+// infersT reports whether the type argument of the generic function called in
+// call can be inferred from the arguments. This needs an argument of a typed
+// expression for a parameter of a generic type. Constants are not enough, if
+// they are untyped they would make T their default type float64.
+func infersT(c *checkedPkg, call *ast.CallExpr, gen map[types.Object]bool) bool {
+	sig, ok := c.info.Types[call.Fun].Type.(*types.Signature)
+	if !ok {
+		return false
+	}
+	params := sig.Params()
+	for i, arg := range call.Args {
+		var param types.Type
+		switch {
+		case i < params.Len()-1 || i < params.Len() && !sig.Variadic():
+			param = params.At(i).Type()
+		case sig.Variadic():
+			param = params.At(params.Len() - 1).Type().(*types.Slice).Elem()
+			if call.Ellipsis.IsValid() {
+				param = params.At(params.Len() - 1).Type()
+			}
+		}
+		if param == nil || !usesFloat32(param, gen) {
+			continue
+		}
+		if tv := c.info.Types[arg]; tv.Value != nil {
+			// The type checker records the type that an untyped constant
+			// is converted to, but T cannot be inferred from it.
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// usesFloat32 reports whether the type t contains float32 or a generic type.
+func usesFloat32(t types.Type, gen map[types.Object]bool) bool {
+	switch t := t.(type) {
+	case *types.Basic:
+		return t.Kind() == types.Float32
+	case *types.Named:
+		return gen[t.Obj()]
+	case *types.Array:
+		return usesFloat32(t.Elem(), gen)
+	case *types.Slice:
+		return usesFloat32(t.Elem(), gen)
+	case *types.Pointer:
+		return usesFloat32(t.Elem(), gen)
+	}
+	return false
+}
+
+// testKind returns "Test" or "Benchmark" if fn is a test or benchmark and
+// otherwise "".
+func testKind(fn *ast.FuncDecl) string {
+	if fn.Recv != nil {
+		return ""
+	}
+	for _, kind := range []string{"Test", "Benchmark"} {
+		if strings.HasPrefix(fn.Name.Name, kind) {
+			return kind
+		}
+	}
+	return ""
+}
+
+// runForFloats renames the test or benchmark fn, which becomes generic, to
+// start in lower case and adds a test or benchmark of the original name after
+// it, which runs it for float32 and float64.
+func (e *edits) runForFloats(c *checkedPkg, fn *ast.FuncDecl, kind string) {
+	name := fn.Name.Name
+	generic := strings.ToLower(kind[:1]) + name[1:]
+	e.replace(c, fn.Name, generic)
+	t := "t"
+	if kind == "Benchmark" {
+		t = "b"
+	}
+	e.insert(c, fn.End(), "\n\nfunc "+name+"("+t+" *testing."+kind[:1]+") {\n"+
+		t+".Run(\"float32\", "+generic+"[float32])\n"+
+		t+".Run(\"float64\", "+generic+"[float64])\n}")
+}
@@ -0,0 +1,283 @@
+/*
+Command gen generates all d3dmath packages from the hand-written package
+row_major/d3dmath, so that every function exists with the same semantics in
+every package. It is run with go generate in row_major/d3dmath, or from the
+module root with
+
+	go run ./internal/gen
+
+The packages are derived from each other in these steps:
+
+	row_major/d3dmath      written by hand
+	column_major/d3dmath   row_major/d3dmath with transposed matrices
+	row_major/d3dmath64    row_major/d3dmath with float64 instead of float32
+	column_major/d3dmath64 row_major/d3dmath64 with transposed matrices
+	generic/row_major      row_major/d3dmath made generic over the float type
+	generic/column_major   column_major/d3dmath made generic
+
+Tests are generated along with the code. In the generic packages they become
+generic functions, which run for both float32 and float64.
+
+Transposing a matrix changes constant indexes into it, e.g. m[1] becomes m[4] in
+a Mat4, and the order of the elements of matrix literals. Trailing float
+arguments after a matrix slice m[:], as in checkFloats(t, m[:], 1, 2, 3, 4), are
+reordered as well. Any other use of a matrix that depends on its memory layout
+is an error. Such code belongs into the hand-written file layout.go, which
+exists in each float32 package.
+
+Some files are written by hand. Every package has its own doc.go with the
+package documentation and both float32 packages have their own layout.go. The
+precision dependent code lives in float32.go, float64.go and float.go and their
+tests, and the conversions from float32 in row_major/d3dmath64/convert.go. The
+column-major packages get transposed copies of these files, except for doc.go
+and layout.go. All generated files start with a "Code generated" line and must
+not be edited.
+*/
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/importer"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const modulePath = "github.com/gonutz/d3dmath"
+
+// A step derives the package in directory to from the package in directory
+// from, both relative to the module root. The files listed in skip are not
+// carried over, the target package has its own versions of them if it needs
+// them.
+type step struct {
+	from, to  string
+	transform func(g *generator, j *job) (map[string][]byte, error)
+	skip      []string
+}
+
+var steps = []step{
+	{
+		from:      "row_major/d3dmath",
+		to:        "column_major/d3dmath",
+		transform: (*generator).transpose,
+		skip:      []string{"doc.go", "layout.go"},
+	},
+	{
+		from:      "row_major/d3dmath",
+		to:        "row_major/d3dmath64",
+		transform: (*generator).toFloat64,
+		skip: []string{
+			"doc.go", "layout.go",
+			"float32.go", "float32_test.go", "simd_test.go",
+		},
+	},
+	{
+		from:      "row_major/d3dmath64",
+		to:        "column_major/d3dmath64",
+		transform: (*generator).transpose,
+		skip:      []string{"doc.go"},
+	},
+	{
+		from:      "row_major/d3dmath",
+		to:        "generic/row_major/d3dmath",
+		transform: (*generator).toGeneric,
+		skip: []string{
+			"doc.go", "layout.go",
+			"float32.go", "float32_test.go", "simd_test.go",
+		},
+	},
+	{
+		from:      "column_major/d3dmath",
+		to:        "generic/column_major/d3dmath",
+		transform: (*generator).toGeneric,
+		skip: []string{
+			"doc.go", "layout.go",
+			"float32.go", "float32_test.go", "simd_test.go",
+		},
+	},
+}
+
+func main() {
+	root, err := moduleRoot()
+	if err == nil {
+		var files map[string][]byte
+		files, err = generate(root)
+		if err == nil {
+			err = write(root, files)
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "gen:", err)
+		os.Exit(1)
+	}
+}
+
+// moduleRoot returns the directory of the d3dmath module, starting the search
+// in the working directory.
+func moduleRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		mod, err := ioutil.ReadFile(filepath.Join(dir, "go.mod"))
+		if err == nil && strings.HasPrefix(string(mod), "module "+modulePath+"\n") {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("module " + modulePath + " not found")
+		}
+		dir = parent
+	}
+}
+
+// generate returns the contents of all generated files, by their slash
+// separated paths relative to root.
+func generate(root string) (map[string][]byte, error) {
+	g := &generator{
+		root: root,
+		fset: token.NewFileSet(),
+		pkgs: make(map[string]pkg),
+	}
+	g.importer = importer.ForCompiler(g.fset, "source", nil)
+	files := make(map[string][]byte)
+	for _, s := range steps {
+		from, err := g.load(s.from)
+		if err != nil {
+			return nil, err
+		}
+		// The target package consists of its hand-written files, which are
+		// read from disk, and the generated files.
+		to, err := readPkg(root, s.to)
+		if err != nil {
+			return nil, err
+		}
+		for name, data := range to.files {
+			if isGenerated(data) {
+				delete(to.files, name)
+			}
+		}
+
+		j := &job{
+			src:     &pkg{dir: s.from, files: make(map[string][]byte)},
+			skipped: make(map[string][]byte),
+			dst:     &to,
+		}
+		for name, data := range from.files {
+			if contains(s.skip, name) {
+				j.skipped[name] = data
+			} else {
+				j.src.files[name] = data
+			}
+		}
+		generated, err := s.transform(g, j)
+		if err != nil {
+			return nil, fmt.Errorf("generating %s: %v", s.to, err)
+		}
+		for name, data := range generated {
+			if _, ok := to.files[name]; ok {
+				return nil, fmt.Errorf("%s/%s is written by hand but also generated", s.to, name)
+			}
+			data = append([]byte(fmt.Sprintf(header, s.from+"/"+name)), data...)
+			to.files[name] = data
+			files[s.to+"/"+name] = data
+		}
+		g.pkgs[s.to] = to
+	}
+	return files, nil
+}
+
+// A job is the input of a transform.
+type job struct {
+	// src holds the files to transform.
+	src *pkg
+	// skipped holds the files of the source package that are not
+	// transformed. They are needed to type-check the source.
+	skipped map[string][]byte
+	// dst holds the hand-written files of the target package.
+	dst *pkg
+}
+
+const header = "// Code generated by internal/gen from %s. DO NOT EDIT.\n\n"
+
+// isGenerated reports whether the Go source src starts with a generated
+// header, see https://golang.org/s/generatedcode.
+func isGenerated(src []byte) bool {
+	line := src
+	if i := bytes.IndexByte(src, '\n'); i != -1 {
+		line = src[:i]
+	}
+	return bytes.HasPrefix(line, []byte("// Code generated ")) &&
+		bytes.HasSuffix(line, []byte(" DO NOT EDIT."))
+}
+
+// write writes the generated files to disk and removes all previously
+// generated files that are no longer generated. Unchanged files are not
+// touched.
+func write(root string, files map[string][]byte) error {
+	for _, s := range steps {
+		old, err := readPkg(root, s.to)
+		if err != nil {
+			return err
+		}
+		for name, data := range old.files {
+			if isGenerated(data) {
+				if _, ok := files[s.to+"/"+name]; !ok {
+					if err := os.Remove(filepath.Join(root, s.to, name)); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	var paths []string
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		name := filepath.Join(root, filepath.FromSlash(path))
+		if old, err := ioutil.ReadFile(name); err == nil && bytes.Equal(old, files[path]) {
+			continue
+		}
+		if err := ioutil.WriteFile(name, files[path], 0666); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readPkg reads all Go files in the directory dir relative to root.
+func readPkg(root, dir string) (pkg, error) {
+	p := pkg{dir: dir, files: make(map[string][]byte)}
+	infos, err := ioutil.ReadDir(filepath.Join(root, filepath.FromSlash(dir)))
+	if err != nil {
+		return p, err
+	}
+	for _, info := range infos {
+		if info.IsDir() || !strings.HasSuffix(info.Name(), ".go") {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(root, filepath.FromSlash(dir), info.Name()))
+		if err != nil {
+			return p, err
+		}
+		p.files[info.Name()] = data
+	}
+	return p, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, x := range list {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+)
+
+// float64Names maps the float32 functions and constants of package math to
+// their float64 versions.
+var float64Names = map[string]string{
+	"Float32bits":            "Float64bits",
+	"Float32frombits":        "Float64frombits",
+	"Nextafter32":            "Nextafter",
+	"MaxFloat32":             "MaxFloat64",
+	"SmallestNonzeroFloat32": "SmallestNonzeroFloat64",
+}
+
+// float64Words are replaced in comments.
+var float64Words = map[string]string{
+	"float32":  "float64",
+	"float32s": "float64s",
+}
+
+// toFloat64 converts the package p from float32 to float64. The SIMD code is
+// removed and conversions that become redundant, like float64(math.Sqrt(x)),
+// are simplified.
+func (g *generator) toFloat64(j *job) (map[string][]byte, error) {
+	c, err := g.check(j.src, j.skipped)
+	if err != nil {
+		return nil, err
+	}
+	out := &pkg{dir: j.dst.dir, files: make(map[string][]byte)}
+	for _, name := range j.src.names() {
+		f := c.asts[name]
+		var e edits
+		e.replace(c, f.Name, f.Name.Name+"64")
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.Ident:
+				if c.isUniverse(n, "float32") {
+					e.replace(c, n, "float64")
+				}
+			case *ast.SelectorExpr:
+				if to, ok := float64Names[n.Sel.Name]; ok && c.isPkg(n.X, "math") {
+					e.replace(c, n.Sel, to)
+				}
+			}
+			return true
+		})
+		e.replaceInComments(c, f, float64Words)
+		e.stripSIMD(c, name)
+		src, err := e.apply(j.src.files[name])
+		if err == nil {
+			src, err = removeUnusedImports(src)
+		}
+		if err == nil {
+			src, err = format.Source(src)
+		}
+		if err != nil {
+			return nil, err
+		}
+		out.files[name] = src
+	}
+
+	// Simplify the code until nothing changes. Every round needs to
+	// type-check the package again.
+	for {
+		c, err := g.check(out, j.dst.files)
+		if err != nil {
+			return nil, err
+		}
+		changed := false
+		for _, name := range out.names() {
+			var e edits
+			e.removeConversions(c, name)
+			e.mergeAssignments(c, name)
+			if len(e) == 0 {
+				continue
+			}
+			changed = true
+			out.files[name], err = c.applyAndFormat(name, e)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if !changed {
+			return out.files, nil
+		}
+	}
+}
+
+// removeConversions removes the conversions float64(x) where x is already a
+// float64 variable or expression.
+func (e *edits) removeConversions(c *checkedPkg, name string) {
+	var stack []ast.Node
+	ast.Inspect(c.asts[name], func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		var parent ast.Node
+		if len(stack) > 0 {
+			parent = stack[len(stack)-1]
+		}
+		stack = append(stack, n)
+
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) != 1 {
+			return true
+		}
+		if id, ok := call.Fun.(*ast.Ident); !ok || !c.isUniverse(id, "float64") {
+			return true
+		}
+		arg := call.Args[0]
+		tv := c.info.Types[arg]
+		if tv.Value != nil || !types.Identical(tv.Type, types.Typ[types.Float64]) {
+			// Constants keep their conversions, they might be untyped.
+			return true
+		}
+		text := c.text(name, arg)
+		if _, ok := arg.(*ast.BinaryExpr); ok {
+			switch parent.(type) {
+			case *ast.BinaryExpr, *ast.UnaryExpr, *ast.SelectorExpr:
+				text = "(" + text + ")"
+			}
+		}
+		e.replace(c, call, text)
+		// Nested conversions are removed in the next round.
+		stack = stack[:len(stack)-1]
+		return false
+	})
+}
+
+// mergeAssignments merges the assignment of a tuple into other variables
+// with the definition of the tuple. Removing the conversions from
+//
+//	s, c := math.Sincos(a)
+//	sin, cos := float32(s), float32(c)
+//
+// leaves the second line as sin, cos := s, c, which is merged to
+//
+//	sin, cos := math.Sincos(a)
+func (e *edits) mergeAssignments(c *checkedPkg, name string) {
+	uses := make(map[types.Object]int)
+	for _, obj := range c.info.Uses {
+		uses[obj]++
+	}
+	ast.Inspect(c.asts[name], func(n ast.Node) bool {
+		block, ok := n.(*ast.BlockStmt)
+		if !ok {
+			return true
+		}
+		for i := 1; i < len(block.List); i++ {
+			first, ok1 := block.List[i-1].(*ast.AssignStmt)
+			second, ok2 := block.List[i].(*ast.AssignStmt)
+			if !ok1 || !ok2 || first.Tok != token.DEFINE || second.Tok != token.DEFINE ||
+				len(first.Lhs) != len(second.Rhs) || len(second.Lhs) != len(second.Rhs) {
+				continue
+			}
+			match := true
+			for k, lhs := range first.Lhs {
+				l, ok1 := lhs.(*ast.Ident)
+				r, ok2 := second.Rhs[k].(*ast.Ident)
+				if !ok1 || !ok2 || c.info.Defs[l] == nil ||
+					c.info.Defs[l] != c.info.Uses[r] || uses[c.info.Defs[l]] != 1 {
+					match = false
+				}
+			}
+			if !match {
+				continue
+			}
+			lhs := c.text(name, second.Lhs[0])
+			for _, x := range second.Lhs[1:] {
+				lhs += ", " + c.text(name, x)
+			}
+			*e = append(*e, edit{
+				c.offset(first.Lhs[0].Pos()),
+				c.offset(first.Lhs[len(first.Lhs)-1].End()),
+				lhs,
+			})
+			e.removeLines(c, name, second.Pos(), second.End())
+			i++
+		}
+		return true
+	})
+}
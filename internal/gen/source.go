@@ -0,0 +1,232 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// A pkg holds the Go files of a package by their names. dir is the package
+// directory relative to the module root.
+type pkg struct {
+	dir   string
+	files map[string][]byte
+}
+
+func (p *pkg) names() []string {
+	var names []string
+	for name := range p.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type generator struct {
+	root     string
+	fset     *token.FileSet
+	importer types.Importer
+	// pkgs holds the packages that have been generated so far, by their
+	// directories.
+	pkgs map[string]pkg
+}
+
+// load returns the package in directory dir, either a generated one or the
+// one on disk.
+func (g *generator) load(dir string) (pkg, error) {
+	if p, ok := g.pkgs[dir]; ok {
+		return p, nil
+	}
+	return readPkg(g.root, dir)
+}
+
+// A checkedPkg is a parsed and type-checked package.
+type checkedPkg struct {
+	src   *pkg
+	fset  *token.FileSet
+	asts  map[string]*ast.File
+	types *types.Package
+	info  *types.Info
+}
+
+// check parses and type-checks the files of p together with the additional
+// files in extra, which are not transformed but are needed to type-check p,
+// e.g. hand-written files of the target package.
+func (g *generator) check(p *pkg, extra map[string][]byte) (*checkedPkg, error) {
+	c := &checkedPkg{
+		src:  p,
+		fset: g.fset,
+		asts: make(map[string]*ast.File),
+		info: &types.Info{
+			Types: make(map[ast.Expr]types.TypeAndValue),
+			Defs:  make(map[*ast.Ident]types.Object),
+			Uses:  make(map[*ast.Ident]types.Object),
+		},
+	}
+	var files []*ast.File
+	parse := func(name string, src []byte) (*ast.File, error) {
+		return parser.ParseFile(g.fset, p.dir+"/"+name, src, parser.ParseComments)
+	}
+	for _, name := range p.names() {
+		f, err := parse(name, p.files[name])
+		if err != nil {
+			return nil, err
+		}
+		c.asts[name] = f
+		files = append(files, f)
+	}
+	for name, src := range extra {
+		if _, ok := p.files[name]; ok {
+			continue
+		}
+		f, err := parse(name, src)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	conf := types.Config{Importer: g.importer}
+	var err error
+	c.types, err = conf.Check(modulePath+"/"+p.dir, g.fset, files, c.info)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// offset returns the byte offset of pos in its file.
+func (c *checkedPkg) offset(pos token.Pos) int {
+	return c.fset.Position(pos).Offset
+}
+
+// text returns the source code of node n in file name.
+func (c *checkedPkg) text(name string, n ast.Node) string {
+	return string(c.src.files[name][c.offset(n.Pos()):c.offset(n.End())])
+}
+
+// isPkg reports whether the expression e is the identifier of an imported
+// package with the given path.
+func (c *checkedPkg) isPkg(e ast.Expr, path string) bool {
+	id, ok := e.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	name, ok := c.info.Uses[id].(*types.PkgName)
+	return ok && name.Imported().Path() == path
+}
+
+// isUniverse reports whether the identifier id denotes the predeclared object
+// with the given name, e.g. the type float32.
+func (c *checkedPkg) isUniverse(id *ast.Ident, name string) bool {
+	obj := c.info.Uses[id]
+	return obj != nil && obj == types.Universe.Lookup(name)
+}
+
+// An edit replaces the bytes from start to end with text.
+type edit struct {
+	start, end int
+	text       string
+}
+
+// edits collects the changes to a file. They must not overlap, except that
+// edits within removed code are ignored.
+type edits []edit
+
+func (e *edits) replace(c *checkedPkg, n ast.Node, text string) {
+	*e = append(*e, edit{c.offset(n.Pos()), c.offset(n.End()), text})
+}
+
+func (e *edits) insert(c *checkedPkg, pos token.Pos, text string) {
+	*e = append(*e, edit{c.offset(pos), c.offset(pos), text})
+}
+
+// apply returns src with the edits applied.
+func (e edits) apply(src []byte) ([]byte, error) {
+	sort.SliceStable(e, func(i, j int) bool {
+		if e[i].start != e[j].start {
+			return e[i].start < e[j].start
+		}
+		return e[i].end > e[j].end
+	})
+	var buf bytes.Buffer
+	last, removed := 0, false
+	for _, x := range e {
+		if x.start < last {
+			if removed && x.end <= last {
+				continue
+			}
+			return nil, fmt.Errorf("overlapping edits at offset %d", x.start)
+		}
+		removed = x.text == "" && x.end > x.start
+		buf.Write(src[last:x.start])
+		buf.WriteString(x.text)
+		last = x.end
+	}
+	buf.Write(src[last:])
+	return buf.Bytes(), nil
+}
+
+// applyAndFormat applies the edits to the file name of c and formats the
+// result.
+func (c *checkedPkg) applyAndFormat(name string, e edits) ([]byte, error) {
+	src, err := e.apply(c.src.files[name])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	out, err := format.Source(src)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	return out, nil
+}
+
+// replaceWords replaces all occurrences of the keys of words with their
+// values, as long as they are not part of a longer identifier.
+func replaceWords(s string, words map[string]string) string {
+	var keys []string
+	for k := range words {
+		keys = append(keys, k)
+	}
+	// Replace longer words first, in case one contains another.
+	sort.Slice(keys, func(i, j int) bool { return len(keys[i]) > len(keys[j]) })
+	var buf strings.Builder
+	for i := 0; i < len(s); {
+		replaced := false
+		for _, k := range keys {
+			if strings.HasPrefix(s[i:], k) &&
+				(i == 0 || !isIdentByte(s[i-1])) &&
+				(i+len(k) == len(s) || !isIdentByte(s[i+len(k)])) {
+				buf.WriteString(words[k])
+				i += len(k)
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			buf.WriteByte(s[i])
+			i++
+		}
+	}
+	return buf.String()
+}
+
+func isIdentByte(b byte) bool {
+	return b == '_' || '0' <= b && b <= '9' || 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z'
+}
+
+// replaceInComments replaces words in all comments of f, see replaceWords.
+func (e *edits) replaceInComments(c *checkedPkg, f *ast.File, words map[string]string) {
+	for _, group := range f.Comments {
+		for _, comment := range group.List {
+			if text := replaceWords(comment.Text, words); text != comment.Text {
+				e.replace(c, comment, text)
+			}
+		}
+	}
+}
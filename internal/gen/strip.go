@@ -0,0 +1,174 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+const simdPath = modulePath + "/internal/simd"
+
+// stripSIMD removes the SIMD code paths from file name of c. Package simd only
+// works on float32, the float64 and generic packages use the Go code. The
+// removed parts are the statements
+//
+//	if simd.Enabled { ... }
+//
+// all functions that use package simd and all comment paragraphs that mention
+// SIMD. Imports that become unused must be removed afterwards with
+// removeUnusedImports.
+func (e *edits) stripSIMD(c *checkedPkg, name string) {
+	f := c.asts[name]
+	removed := func(n ast.Node) bool {
+		for _, x := range *e {
+			if x.start <= c.offset(n.Pos()) && c.offset(n.End()) <= x.end {
+				return true
+			}
+		}
+		return false
+	}
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		usesSIMD := false
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok && c.isPkg(sel.X, simdPath) {
+				usesSIMD = true
+			}
+			return !usesSIMD
+		})
+		if !usesSIMD {
+			continue
+		}
+		var pos token.Pos = fn.Pos()
+		if fn.Doc != nil {
+			pos = fn.Doc.Pos()
+		}
+		ifs := 0
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			if s, ok := n.(*ast.IfStmt); ok && s.Else == nil && s.Init == nil {
+				if sel, ok := s.Cond.(*ast.SelectorExpr); ok &&
+					c.isPkg(sel.X, simdPath) && sel.Sel.Name == "Enabled" {
+					e.removeLines(c, name, s.Pos(), s.End())
+					ifs++
+					return false
+				}
+			}
+			return true
+		})
+		if ifs == 0 {
+			// The function only exists for the SIMD code.
+			e.removeLines(c, name, pos, fn.End())
+		}
+	}
+	for _, group := range f.Comments {
+		if removed(group) || !strings.Contains(group.Text(), "SIMD") {
+			continue
+		}
+		var paragraphs [][]string
+		var current []string
+		for _, comment := range group.List {
+			if comment.Text == "//" {
+				paragraphs = append(paragraphs, current)
+				current = nil
+			} else {
+				current = append(current, comment.Text)
+			}
+		}
+		paragraphs = append(paragraphs, current)
+		var kept []string
+		for _, p := range paragraphs {
+			if !strings.Contains(strings.Join(p, "\n"), "SIMD") {
+				kept = append(kept, strings.Join(p, "\n"))
+			}
+		}
+		if len(kept) == 0 {
+			e.removeLines(c, name, group.Pos(), group.End())
+		} else {
+			e.replace(c, group, strings.Join(kept, "\n//\n"))
+		}
+	}
+}
+
+// removeLines removes the complete lines from pos to end.
+func (e *edits) removeLines(c *checkedPkg, name string, pos, end token.Pos) {
+	src := c.src.files[name]
+	start, stop := c.offset(pos), c.offset(end)
+	for start > 0 && src[start-1] != '\n' {
+		start--
+	}
+	for stop < len(src) && src[stop] != '\n' {
+		stop++
+	}
+	if stop < len(src) {
+		stop++
+	}
+	*e = append(*e, edit{start, stop, ""})
+}
+
+// removeUnusedImports removes the imports that the Go source src does not
+// use. Blank and dot imports are kept.
+func removeUnusedImports(src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.ImportsOnly)
+	if err != nil {
+		return nil, err
+	}
+	full, err := parser.ParseFile(fset, "", src, 0)
+	if err != nil {
+		return nil, err
+	}
+	used := make(map[string]bool)
+	ast.Inspect(full, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok {
+				used[id.Name] = true
+			}
+		}
+		return true
+	})
+	var e edits
+	offset := func(pos token.Pos) int { return fset.Position(pos).Offset }
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		unused := 0
+		var specEdits edits
+		for _, spec := range gen.Specs {
+			spec := spec.(*ast.ImportSpec)
+			path, _ := strconv.Unquote(spec.Path.Value)
+			name := path[strings.LastIndex(path, "/")+1:]
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			if name == "_" || name == "." || used[name] {
+				continue
+			}
+			unused++
+			start, end := offset(spec.Pos()), offset(spec.End())
+			for start > 0 && src[start-1] != '\n' {
+				start--
+			}
+			if end < len(src) && src[end] == '\n' {
+				end++
+			}
+			specEdits = append(specEdits, edit{start, end, ""})
+		}
+		if unused == len(gen.Specs) {
+			end := offset(gen.End())
+			if end < len(src) && src[end] == '\n' {
+				end++
+			}
+			e = append(e, edit{offset(gen.Pos()), end, ""})
+		} else {
+			e = append(e, specEdits...)
+		}
+	}
+	return e.apply(src)
+}
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// matrixShapes are the numbers of rows and columns of the matrix types.
+var matrixShapes = map[string][2]int{
+	"Mat2":   {2, 2},
+	"Mat3":   {3, 3},
+	"Mat2x3": {2, 3},
+	"Mat4":   {4, 4},
+}
+
+// layoutWords are swapped in comments and strings when transposing.
+var layoutWords = map[string]string{
+	"row-major":    "column-major",
+	"column-major": "row-major",
+	"Row-major":    "Column-major",
+	"Column-major": "Row-major",
+	"row_major":    "column_major",
+	"column_major": "row_major",
+}
+
+// transpose converts the package p from row-major to column-major matrices.
+// The math stays the same, only the memory layout changes.
+func (g *generator) transpose(j *job) (map[string][]byte, error) {
+	out := make(map[string][]byte)
+	c, err := g.check(j.src, j.skipped)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range j.src.names() {
+		var e edits
+		t := transposer{c: c, name: name}
+		if err := t.file(c.asts[name]); err != nil {
+			return nil, err
+		}
+		e.replaceInComments(c, c.asts[name], layoutWords)
+		e = append(e, t.finish()...)
+		out[name], err = c.applyAndFormat(name, e)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// A transposer collects the edits that transpose the matrices in one file.
+type transposer struct {
+	c    *checkedPkg
+	name string
+	// edits holds the changes to single expressions, e.g. indexes. They may
+	// lie within the lists of matrix elements that are reordered.
+	edits    edits
+	reorders []reordering
+	// elementwise holds the range keys of loops over matrices. They may
+	// index matrices because they visit all elements in memory order.
+	elementwise map[types.Object]bool
+	err         error
+}
+
+func (t *transposer) errorf(pos token.Pos, format string, a ...interface{}) {
+	if t.err == nil {
+		t.err = fmt.Errorf("%s: %s", t.c.fset.Position(pos), fmt.Sprintf(format, a...))
+	}
+}
+
+func (t *transposer) file(f *ast.File) error {
+	t.elementwise = make(map[types.Object]bool)
+	ast.Inspect(f, func(n ast.Node) bool {
+		if r, ok := n.(*ast.RangeStmt); ok && r.Key != nil {
+			if _, _, ok := t.shape(r.X); ok {
+				if id, ok := r.Key.(*ast.Ident); ok {
+					obj := t.c.info.Defs[id]
+					if obj == nil {
+						obj = t.c.info.Uses[id]
+					}
+					t.elementwise[obj] = true
+				}
+			}
+		}
+		return true
+	})
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.IndexExpr:
+			t.index(n)
+		case *ast.SliceExpr:
+			if _, _, ok := t.shape(n.X); ok && (n.Low != nil || n.High != nil || n.Max != nil) {
+				t.errorf(n.Pos(), "partial matrix slice %s", t.c.text(t.name, n))
+			}
+		case *ast.CompositeLit:
+			t.literal(n)
+		case *ast.CallExpr:
+			t.call(n)
+		case *ast.BasicLit:
+			if n.Kind == token.STRING {
+				if s := replaceWords(n.Value, layoutWords); s != n.Value {
+					t.edits.replace(t.c, n, s)
+				}
+			}
+		}
+		return t.err == nil
+	})
+	return t.err
+}
+
+// shape returns the number of rows and columns if e is a matrix or a pointer
+// to one.
+func (t *transposer) shape(e ast.Expr) (rows, cols int, ok bool) {
+	typ := t.c.info.Types[e].Type
+	if p, isPtr := typ.(*types.Pointer); isPtr {
+		typ = p.Elem()
+	}
+	// The matrices of the other row-major packages, e.g. in conversions
+	// between float32 and float64, are transposed along with the package.
+	named, isNamed := typ.(*types.Named)
+	if !isNamed || named.Obj().Pkg() == nil ||
+		!strings.HasPrefix(named.Obj().Pkg().Path(), modulePath+"/row_major/") {
+		return 0, 0, false
+	}
+	s, ok := matrixShapes[named.Obj().Name()]
+	return s[0], s[1], ok
+}
+
+// transposed returns the index of the element in the transposed layout.
+func transposed(i, rows, cols int) int {
+	return i%cols*rows + i/cols
+}
+
+func (t *transposer) index(n *ast.IndexExpr) {
+	rows, cols, ok := t.shape(n.X)
+	if !ok {
+		return
+	}
+	tv := t.c.info.Types[n.Index]
+	if tv.Value == nil {
+		if id, isIdent := n.Index.(*ast.Ident); isIdent && t.elementwise[t.c.info.Uses[id]] {
+			return
+		}
+		t.errorf(n.Pos(), "non-constant matrix index %s", t.c.text(t.name, n))
+		return
+	}
+	i, _ := constant.Int64Val(tv.Value)
+	t.edits.replace(t.c, n.Index, strconv.Itoa(transposed(int(i), rows, cols)))
+}
+
+func (t *transposer) literal(n *ast.CompositeLit) {
+	rows, cols, ok := t.shape(n)
+	if !ok || len(n.Elts) == 0 {
+		return
+	}
+	if _, keyed := n.Elts[0].(*ast.KeyValueExpr); keyed {
+		t.errorf(n.Pos(), "keyed matrix literal")
+		return
+	}
+	if len(n.Elts) != rows*cols {
+		t.errorf(n.Pos(), "matrix literal with %d of %d elements", len(n.Elts), rows*cols)
+		return
+	}
+	t.reorder(n.Elts, rows, cols)
+}
+
+// call reorders the arguments after a matrix slice m[:] if there is one for
+// each element, e.g. in checkFloats(t, m[:], 1, 2, 3, 4).
+func (t *transposer) call(n *ast.CallExpr) {
+	if t.c.info.Types[n.Fun].IsType() {
+		// Converting a matrix to or from any other type, e.g. an array,
+		// exposes the memory layout.
+		_, _, from := t.shape(n.Args[0])
+		_, _, to := t.shape(n)
+		if (from || to) && !types.Identical(t.c.info.Types[n.Args[0]].Type, t.c.info.Types[n].Type) {
+			t.errorf(n.Pos(), "matrix conversion %s", t.c.text(t.name, n))
+		}
+		return
+	}
+	if n.Ellipsis.IsValid() {
+		return
+	}
+	for i, arg := range n.Args {
+		s, ok := arg.(*ast.SliceExpr)
+		if !ok {
+			continue
+		}
+		rows, cols, ok := t.shape(s.X)
+		if ok && len(n.Args)-i-1 == rows*cols {
+			t.reorder(n.Args[i+1:], rows, cols)
+		}
+	}
+}
+
+// A reordering transposes a row-major list of matrix elements.
+type reordering struct {
+	elems      []ast.Expr
+	rows, cols int
+	// sep returns the separator in front of element i of the result.
+	sep func(i int) string
+}
+
+// reorder transposes the row-major list of matrix elements. The elements keep
+// their arrangement in lines, i.e. if the original has one row per line, the
+// result has one column per line.
+func (t *transposer) reorder(elems []ast.Expr, rows, cols int) {
+	for _, e := range elems {
+		if t.hasComment(e) {
+			t.errorf(e.Pos(), "comment in matrix elements")
+			return
+		}
+	}
+	line := func(i int) int { return t.c.fset.Position(elems[i].Pos()).Line }
+	first, last := line(0), line(len(elems)-1)
+
+	sep := func(i int) string { return ", " }
+	if first != last {
+		perLine := 1
+		for perLine < len(elems) && line(perLine) == first {
+			perLine++
+		}
+		switch {
+		case perLine == 1:
+			// One element per line, maybe with empty lines between rows.
+			blank := cols < len(elems) && line(cols)-line(cols-1) > 1
+			sep = func(i int) string {
+				if blank && i%rows == 0 {
+					return ",\n\n"
+				}
+				return ",\n"
+			}
+		case perLine == cols:
+			sep = func(i int) string {
+				if i%rows == 0 {
+					return ",\n"
+				}
+				return ", "
+			}
+		default:
+			t.errorf(elems[0].Pos(), "cannot arrange %d matrix elements per line", perLine)
+			return
+		}
+	}
+
+	t.reorders = append(t.reorders, reordering{elems, rows, cols, sep})
+}
+
+// finish returns all edits. The expression edits within reordered elements
+// are applied to the elements before they are moved.
+func (t *transposer) finish() edits {
+	var result edits
+	inner := make([]bool, len(t.edits))
+	for _, r := range t.reorders {
+		start := t.c.offset(r.elems[0].Pos())
+		end := t.c.offset(r.elems[len(r.elems)-1].End())
+		var text strings.Builder
+		for i := range r.elems {
+			if i > 0 {
+				text.WriteString(r.sep(i))
+			}
+			e := r.elems[i%r.rows*r.cols+i/r.rows]
+			from, to := t.c.offset(e.Pos()), t.c.offset(e.End())
+			var local edits
+			for j, x := range t.edits {
+				if from <= x.start && x.end <= to {
+					local = append(local, edit{x.start - from, x.end - from, x.text})
+					inner[j] = true
+				}
+			}
+			src, _ := local.apply(t.c.src.files[t.name][from:to])
+			text.Write(src)
+		}
+		result = append(result, edit{start, end, text.String()})
+	}
+	for i, x := range t.edits {
+		if !inner[i] {
+			result = append(result, x)
+		}
+	}
+	return result
+}
+
+func (t *transposer) hasComment(e ast.Expr) bool {
+	for _, g := range t.c.asts[t.name].Comments {
+		if e.Pos() <= g.Pos() && g.End() <= e.End() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+/*
+Package simd transforms float32 vectors by 4 by 4 matrices using SIMD
+instructions where available: SSE on amd64, with AVX for 4-element vectors if
+the CPU supports it, and NEON on arm64. On other architectures, or when
+building with the purego tag, it falls back to plain Go.
+
+All functions multiply row vectors with a matrix m whose rows are stored one
+after another. The results are the same as those of the reference Go
+implementation, bit for bit. Vector i is read from in[i*inStride:] and written
+to out[i*outStride:], so vectors can be packed or interleaved with other data.
+out and in may be the same buffer if the strides are the same.
+*/
+package simd
+
+import "unsafe"
+
+// Enabled reports whether SIMD instructions are used. It is false if this
+// package falls back to plain Go. Callers that have their own Go code for the
+// fallback case check it to avoid the overhead of calling this package.
+var Enabled = enabled
+
+// Implementation returns the name of the instruction set used for the
+// transforms, which is one of "avx", "sse", "neon" or "go".
+func Implementation() string {
+	return implementation()
+}
+
+// Floats returns the n float32s starting at p as a slice. Use it to pass
+// slices of vectors to the transforms without copying them.
+func Floats(p unsafe.Pointer, n int) []float32 {
+	return (*[maxFloats]float32)(p)[:n:n]
+}
+
+// Transform sets the n 4-element vectors in out to the 4-element vectors in in
+// times m.
+func Transform(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32) {
+	if n <= 0 {
+		return
+	}
+	checkStrides(outStride, inStride, n)
+	_ = out[(n-1)*outStride+3]
+	_ = in[(n-1)*inStride+3]
+	transform(out, outStride, in, inStride, n, m)
+}
+
+// TransformPoint sets the n 4-element vectors in out to the 3-element points
+// in in, with w = 1, times m.
+func TransformPoint(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32) {
+	if n <= 0 {
+		return
+	}
+	checkStrides(outStride, inStride, n)
+	_ = out[(n-1)*outStride+3]
+	_ = in[(n-1)*inStride+2]
+	transformPoint(out, outStride, in, inStride, n, m)
+}
+
+// TransformCoord sets the n 3-element points in out to the 3-element points in
+// in, with w = 1, times m, divided by the resulting w.
+func TransformCoord(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32) {
+	if n <= 0 {
+		return
+	}
+	checkStrides(outStride, inStride, n)
+	_ = out[(n-1)*outStride+2]
+	_ = in[(n-1)*inStride+2]
+	transformCoord(out, outStride, in, inStride, n, m)
+}
+
+// TransformNormal sets the n 3-element directions in out to the 3-element
+// directions in in times the upper left 3 by 3 part of m.
+func TransformNormal(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32) {
+	if n <= 0 {
+		return
+	}
+	checkStrides(outStride, inStride, n)
+	_ = out[(n-1)*outStride+2]
+	_ = in[(n-1)*inStride+2]
+	transformNormal(out, outStride, in, inStride, n, m)
+}
+
+// checkStrides panics if a stride is negative. The bounds checks of the last
+// vector do not catch this, but the assembly would then access memory outside
+// of the slices. Like the Go code, it does not panic for a single vector,
+// which is at index 0 for any stride.
+func checkStrides(outStride, inStride, n int) {
+	if n > 1 && (outStride < 0 || inStride < 0) {
+		panic("simd: negative stride")
+	}
+}
+
+// The Go versions below are the reference for the assembly versions. The
+// explicit float32 conversions round every product, which keeps the compiler
+// from fusing multiplications and additions. This way they compute exactly
+// what the SIMD instructions compute.
+
+func transformGo(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32) {
+	for i := 0; i < n; i++ {
+		v := in[i*inStride : i*inStride+4]
+		o := out[i*outStride : i*outStride+4]
+		x, y, z, w := v[0], v[1], v[2], v[3]
+		for c := range o {
+			o[c] = float32(float32(float32(x*m[c])+float32(y*m[4+c]))+
+				float32(z*m[8+c])) + float32(w*m[12+c])
+		}
+	}
+}
+
+func transformPointGo(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32) {
+	for i := 0; i < n; i++ {
+		v := in[i*inStride : i*inStride+3]
+		o := out[i*outStride : i*outStride+4]
+		x, y, z := v[0], v[1], v[2]
+		for c := range o {
+			o[c] = float32(float32(float32(x*m[c])+float32(y*m[4+c]))+
+				float32(z*m[8+c])) + m[12+c]
+		}
+	}
+}
+
+func transformCoordGo(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32) {
+	for i := 0; i < n; i++ {
+		v := in[i*inStride : i*inStride+3]
+		o := out[i*outStride : i*outStride+3]
+		x, y, z := v[0], v[1], v[2]
+		var p [4]float32
+		for c := range p {
+			p[c] = float32(float32(float32(x*m[c])+float32(y*m[4+c]))+
+				float32(z*m[8+c])) + m[12+c]
+		}
+		f := 1 / p[3]
+		o[0], o[1], o[2] = f*p[0], f*p[1], f*p[2]
+	}
+}
+
+func transformNormalGo(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32) {
+	for i := 0; i < n; i++ {
+		v := in[i*inStride : i*inStride+3]
+		o := out[i*outStride : i*outStride+3]
+		x, y, z := v[0], v[1], v[2]
+		for c := range o {
+			o[c] = float32(float32(x*m[c])+float32(y*m[4+c])) +
+				float32(z*m[8+c])
+		}
+	}
+}
@@ -0,0 +1,73 @@
+//go:build !purego
+// +build !purego
+
+package simd
+
+const (
+	enabled   = true
+	maxFloats = 1 << 40
+)
+
+var useAVX = hasAVX()
+
+// hasAVX reports whether the CPU supports AVX and the operating system saves
+// the AVX registers on context switches.
+func hasAVX() bool {
+	const (
+		osxsave = 1 << 27
+		avx     = 1 << 28
+	)
+	_, _, ecx, _ := cpuid(1, 0)
+	if ecx&osxsave == 0 || ecx&avx == 0 {
+		return false
+	}
+	// Bits 1 and 2 are set if the OS saves the SSE and AVX registers.
+	eax, _ := xgetbv()
+	return eax&6 == 6
+}
+
+func implementation() string {
+	if useAVX {
+		return "avx"
+	}
+	return "sse"
+}
+
+func transform(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32) {
+	if useAVX {
+		transformAVX(out, outStride, in, inStride, n, m)
+	} else {
+		transformSSE(out, outStride, in, inStride, n, m)
+	}
+}
+
+func transformPoint(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32) {
+	transformPointSSE(out, outStride, in, inStride, n, m)
+}
+
+func transformCoord(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32) {
+	transformCoordSSE(out, outStride, in, inStride, n, m)
+}
+
+func transformNormal(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32) {
+	transformNormalSSE(out, outStride, in, inStride, n, m)
+}
+
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+
+func xgetbv() (eax, edx uint32)
+
+//go:noescape
+func transformSSE(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32)
+
+//go:noescape
+func transformAVX(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32)
+
+//go:noescape
+func transformPointSSE(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32)
+
+//go:noescape
+func transformCoordSSE(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32)
+
+//go:noescape
+func transformNormalSSE(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32)
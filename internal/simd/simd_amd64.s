@@ -0,0 +1,221 @@
+//go:build !purego
+// +build !purego
+
+#include "textflag.h"
+
+// LOAD_ARGS loads the arguments shared by all transforms: DI = out, R8 =
+// outStride in bytes, SI = in, R9 = inStride in bytes, CX = n, AX = m.
+#define LOAD_ARGS \
+	MOVQ out_base+0(FP), DI \
+	MOVQ outStride+24(FP), R8 \
+	SHLQ $2, R8 \
+	MOVQ in_base+32(FP), SI \
+	MOVQ inStride+56(FP), R9 \
+	SHLQ $2, R9 \
+	MOVQ n+64(FP), CX \
+	MOVQ m+72(FP), AX
+
+// LOAD_ROWS loads the rows of m into X4 to X7.
+#define LOAD_ROWS \
+	MOVUPS 0(AX), X4 \
+	MOVUPS 16(AX), X5 \
+	MOVUPS 32(AX), X6 \
+	MOVUPS 48(AX), X7
+
+// POINT computes x*X4 + y*X5 + z*X6 + X7 into X1 for the point at (SI).
+#define POINT \
+	MOVSS 0(SI), X1 \
+	SHUFPS $0x00, X1, X1 \
+	MULPS X4, X1 \
+	MOVSS 4(SI), X2 \
+	SHUFPS $0x00, X2, X2 \
+	MULPS X5, X2 \
+	ADDPS X2, X1 \
+	MOVSS 8(SI), X2 \
+	SHUFPS $0x00, X2, X2 \
+	MULPS X6, X2 \
+	ADDPS X2, X1 \
+	ADDPS X7, X1
+
+// STORE3 writes the first 3 elements of X1 to (DI).
+#define STORE3 \
+	MOVSD X1, 0(DI) \
+	MOVHLPS X1, X2 \
+	MOVSS X2, 8(DI)
+
+// func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+TEXT ·cpuid(SB), NOSPLIT, $0-24
+	MOVL eaxArg+0(FP), AX
+	MOVL ecxArg+4(FP), CX
+	CPUID
+	MOVL AX, eax+8(FP)
+	MOVL BX, ebx+12(FP)
+	MOVL CX, ecx+16(FP)
+	MOVL DX, edx+20(FP)
+	RET
+
+// func xgetbv() (eax, edx uint32)
+TEXT ·xgetbv(SB), NOSPLIT, $0-8
+	MOVL $0, CX
+	XGETBV
+	MOVL AX, eax+0(FP)
+	MOVL DX, edx+4(FP)
+	RET
+
+// func transformSSE(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32)
+TEXT ·transformSSE(SB), NOSPLIT, $0-80
+	LOAD_ARGS
+	LOAD_ROWS
+	TESTQ CX, CX
+	JLE   sse_done
+
+sse_loop:
+	MOVUPS (SI), X0
+	MOVAPS X0, X1
+	SHUFPS $0x00, X1, X1
+	MULPS  X4, X1
+	MOVAPS X0, X2
+	SHUFPS $0x55, X2, X2
+	MULPS  X5, X2
+	ADDPS  X2, X1
+	MOVAPS X0, X2
+	SHUFPS $0xAA, X2, X2
+	MULPS  X6, X2
+	ADDPS  X2, X1
+	SHUFPS $0xFF, X0, X0
+	MULPS  X7, X0
+	ADDPS  X0, X1
+	MOVUPS X1, (DI)
+	ADDQ   R9, SI
+	ADDQ   R8, DI
+	DECQ   CX
+	JNZ    sse_loop
+
+sse_done:
+	RET
+
+// func transformAVX(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32)
+//
+// transformAVX transforms two vectors at once, one in each 128 bit lane of the
+// Y registers.
+TEXT ·transformAVX(SB), NOSPLIT, $0-80
+	LOAD_ARGS
+	VBROADCASTF128 0(AX), Y4
+	VBROADCASTF128 16(AX), Y5
+	VBROADCASTF128 32(AX), Y6
+	VBROADCASTF128 48(AX), Y7
+	CMPQ           CX, $2
+	JL             avx_tail
+
+avx_pair:
+	VMOVUPS      (SI), X0
+	VINSERTF128  $1, (SI)(R9*1), Y0, Y0
+	VSHUFPS      $0x00, Y0, Y0, Y1
+	VMULPS       Y4, Y1, Y1
+	VSHUFPS      $0x55, Y0, Y0, Y2
+	VMULPS       Y5, Y2, Y2
+	VADDPS       Y2, Y1, Y1
+	VSHUFPS      $0xAA, Y0, Y0, Y2
+	VMULPS       Y6, Y2, Y2
+	VADDPS       Y2, Y1, Y1
+	VSHUFPS      $0xFF, Y0, Y0, Y2
+	VMULPS       Y7, Y2, Y2
+	VADDPS       Y2, Y1, Y1
+	VMOVUPS      X1, (DI)
+	VEXTRACTF128 $1, Y1, (DI)(R8*1)
+	LEAQ         (SI)(R9*2), SI
+	LEAQ         (DI)(R8*2), DI
+	SUBQ         $2, CX
+	CMPQ         CX, $2
+	JGE          avx_pair
+
+avx_tail:
+	TESTQ   CX, CX
+	JLE     avx_done
+	VMOVUPS (SI), X0
+	VSHUFPS $0x00, X0, X0, X1
+	VMULPS  X4, X1, X1
+	VSHUFPS $0x55, X0, X0, X2
+	VMULPS  X5, X2, X2
+	VADDPS  X2, X1, X1
+	VSHUFPS $0xAA, X0, X0, X2
+	VMULPS  X6, X2, X2
+	VADDPS  X2, X1, X1
+	VSHUFPS $0xFF, X0, X0, X2
+	VMULPS  X7, X2, X2
+	VADDPS  X2, X1, X1
+	VMOVUPS X1, (DI)
+
+avx_done:
+	VZEROUPPER
+	RET
+
+// func transformPointSSE(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32)
+TEXT ·transformPointSSE(SB), NOSPLIT, $0-80
+	LOAD_ARGS
+	LOAD_ROWS
+	TESTQ CX, CX
+	JLE   point_done
+
+point_loop:
+	POINT
+	MOVUPS X1, (DI)
+	ADDQ   R9, SI
+	ADDQ   R8, DI
+	DECQ   CX
+	JNZ    point_loop
+
+point_done:
+	RET
+
+// func transformCoordSSE(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32)
+TEXT ·transformCoordSSE(SB), NOSPLIT, $0-80
+	LOAD_ARGS
+	LOAD_ROWS
+	TESTQ CX, CX
+	JLE   coord_done
+
+coord_loop:
+	POINT
+	MOVAPS X1, X2
+	SHUFPS $0xFF, X2, X2
+	MOVSS  $(1.0), X3
+	DIVSS  X2, X3
+	SHUFPS $0x00, X3, X3
+	MULPS  X3, X1
+	STORE3
+	ADDQ   R9, SI
+	ADDQ   R8, DI
+	DECQ   CX
+	JNZ    coord_loop
+
+coord_done:
+	RET
+
+// func transformNormalSSE(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32)
+TEXT ·transformNormalSSE(SB), NOSPLIT, $0-80
+	LOAD_ARGS
+	LOAD_ROWS
+	TESTQ CX, CX
+	JLE   normal_done
+
+normal_loop:
+	MOVSS  0(SI), X1
+	SHUFPS $0x00, X1, X1
+	MULPS  X4, X1
+	MOVSS  4(SI), X2
+	SHUFPS $0x00, X2, X2
+	MULPS  X5, X2
+	ADDPS  X2, X1
+	MOVSS  8(SI), X2
+	SHUFPS $0x00, X2, X2
+	MULPS  X6, X2
+	ADDPS  X2, X1
+	STORE3
+	ADDQ   R9, SI
+	ADDQ   R8, DI
+	DECQ   CX
+	JNZ    normal_loop
+
+normal_done:
+	RET
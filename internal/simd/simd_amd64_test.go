@@ -0,0 +1,17 @@
+//go:build !purego
+// +build !purego
+
+package simd
+
+import "testing"
+
+func TestAMD64KernelsMatchGo(t *testing.T) {
+	checkKernel(t, "transformSSE", transformSSE, transformGo, 4, 4)
+	checkInPlace(t, "transformSSE", transformSSE, 4)
+	if hasAVX() {
+		checkKernel(t, "transformAVX", transformAVX, transformGo, 4, 4)
+		checkInPlace(t, "transformAVX", transformAVX, 4)
+	} else {
+		t.Log("AVX is not supported, skipping AVX tests")
+	}
+}
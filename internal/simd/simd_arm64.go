@@ -0,0 +1,41 @@
+//go:build !purego
+// +build !purego
+
+package simd
+
+const (
+	enabled   = true
+	maxFloats = 1 << 40
+)
+
+func implementation() string {
+	return "neon"
+}
+
+func transform(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32) {
+	transformNEON(out, outStride, in, inStride, n, m)
+}
+
+func transformPoint(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32) {
+	transformPointNEON(out, outStride, in, inStride, n, m)
+}
+
+func transformCoord(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32) {
+	transformCoordNEON(out, outStride, in, inStride, n, m)
+}
+
+func transformNormal(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32) {
+	transformNormalNEON(out, outStride, in, inStride, n, m)
+}
+
+//go:noescape
+func transformNEON(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32)
+
+//go:noescape
+func transformPointNEON(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32)
+
+//go:noescape
+func transformCoordNEON(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32)
+
+//go:noescape
+func transformNormalNEON(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32)
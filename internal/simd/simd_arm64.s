@@ -0,0 +1,129 @@
+//go:build !purego
+// +build !purego
+
+#include "textflag.h"
+
+// The vector FMUL and FADD instructions are encoded as WORDs because older
+// versions of the Go assembler do not know them. Every product and sum is
+// rounded separately, fused multiply-add is never used, so the results match
+// the Go reference implementation exactly.
+
+// LOAD_ARGS loads the arguments shared by all transforms: R0 = out, R1 =
+// outStride in bytes, R2 = in, R3 = inStride in bytes, R4 = n and V4 to V7 =
+// the rows of m.
+#define LOAD_ARGS \
+	MOVD out_base+0(FP), R0 \
+	MOVD outStride+24(FP), R1 \
+	LSL  $2, R1, R1 \
+	MOVD in_base+32(FP), R2 \
+	MOVD inStride+56(FP), R3 \
+	LSL  $2, R3, R3 \
+	MOVD n+64(FP), R4 \
+	MOVD m+72(FP), R5 \
+	VLD1 (R5), [V4.S4, V5.S4, V6.S4, V7.S4]
+
+// func transformNEON(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32)
+TEXT ·transformNEON(SB), NOSPLIT, $0-80
+	LOAD_ARGS
+	CMP  $0, R4
+	BLE  transform_done
+
+transform_loop:
+	VLD1 (R2), [V0.S4]
+	WORD $0x4f809081 // FMUL V1.4S, V4.4S, V0.S[0]
+	WORD $0x4fa090a2 // FMUL V2.4S, V5.4S, V0.S[1]
+	WORD $0x4e22d421 // FADD V1.4S, V1.4S, V2.4S
+	WORD $0x4f8098c2 // FMUL V2.4S, V6.4S, V0.S[2]
+	WORD $0x4e22d421 // FADD V1.4S, V1.4S, V2.4S
+	WORD $0x4fa098e2 // FMUL V2.4S, V7.4S, V0.S[3]
+	WORD $0x4e22d421 // FADD V1.4S, V1.4S, V2.4S
+	VST1 [V1.S4], (R0)
+	ADD  R3, R2, R2
+	ADD  R1, R0, R0
+	SUBS $1, R4, R4
+	BNE  transform_loop
+
+transform_done:
+	RET
+
+// func transformPointNEON(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32)
+TEXT ·transformPointNEON(SB), NOSPLIT, $0-80
+	LOAD_ARGS
+	CMP  $0, R4
+	BLE  point_done
+
+point_loop:
+	FMOVS 0(R2), F16
+	FMOVS 4(R2), F17
+	FMOVS 8(R2), F18
+	WORD $0x4f909081 // FMUL V1.4S, V4.4S, V16.S[0]
+	WORD $0x4f9190a2 // FMUL V2.4S, V5.4S, V17.S[0]
+	WORD $0x4e22d421 // FADD V1.4S, V1.4S, V2.4S
+	WORD $0x4f9290c2 // FMUL V2.4S, V6.4S, V18.S[0]
+	WORD $0x4e22d421 // FADD V1.4S, V1.4S, V2.4S
+	WORD $0x4e27d421 // FADD V1.4S, V1.4S, V7.4S
+	VST1 [V1.S4], (R0)
+	ADD  R3, R2, R2
+	ADD  R1, R0, R0
+	SUBS $1, R4, R4
+	BNE  point_loop
+
+point_done:
+	RET
+
+// func transformCoordNEON(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32)
+TEXT ·transformCoordNEON(SB), NOSPLIT, $0-80
+	LOAD_ARGS
+	CMP  $0, R4
+	BLE  coord_done
+
+coord_loop:
+	FMOVS 0(R2), F16
+	FMOVS 4(R2), F17
+	FMOVS 8(R2), F18
+	WORD $0x4f909081 // FMUL V1.4S, V4.4S, V16.S[0]
+	WORD $0x4f9190a2 // FMUL V2.4S, V5.4S, V17.S[0]
+	WORD $0x4e22d421 // FADD V1.4S, V1.4S, V2.4S
+	WORD $0x4f9290c2 // FMUL V2.4S, V6.4S, V18.S[0]
+	WORD $0x4e22d421 // FADD V1.4S, V1.4S, V2.4S
+	WORD $0x4e27d421 // FADD V1.4S, V1.4S, V7.4S
+	VDUP  V1.S[3], V3.S4
+	FMOVS $(1.0), F19
+	FDIVS F3, F19, F19
+	WORD $0x4f939021 // FMUL V1.4S, V1.4S, V19.S[0]
+	FMOVD F1, 0(R0)
+	VMOV  V1.S[2], R6
+	MOVW  R6, 8(R0)
+	ADD  R3, R2, R2
+	ADD  R1, R0, R0
+	SUBS $1, R4, R4
+	BNE  coord_loop
+
+coord_done:
+	RET
+
+// func transformNormalNEON(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32)
+TEXT ·transformNormalNEON(SB), NOSPLIT, $0-80
+	LOAD_ARGS
+	CMP  $0, R4
+	BLE  normal_done
+
+normal_loop:
+	FMOVS 0(R2), F16
+	FMOVS 4(R2), F17
+	FMOVS 8(R2), F18
+	WORD $0x4f909081 // FMUL V1.4S, V4.4S, V16.S[0]
+	WORD $0x4f9190a2 // FMUL V2.4S, V5.4S, V17.S[0]
+	WORD $0x4e22d421 // FADD V1.4S, V1.4S, V2.4S
+	WORD $0x4f9290c2 // FMUL V2.4S, V6.4S, V18.S[0]
+	WORD $0x4e22d421 // FADD V1.4S, V1.4S, V2.4S
+	FMOVD F1, 0(R0)
+	VMOV  V1.S[2], R6
+	MOVW  R6, 8(R0)
+	ADD  R3, R2, R2
+	ADD  R1, R0, R0
+	SUBS $1, R4, R4
+	BNE  normal_loop
+
+normal_done:
+	RET
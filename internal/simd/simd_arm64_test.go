@@ -0,0 +1,13 @@
+//go:build !purego
+// +build !purego
+
+package simd
+
+import "testing"
+
+func TestARM64KernelsMatchGo(t *testing.T) {
+	checkKernel(t, "transformNEON", transformNEON, transformGo, 4, 4)
+	checkKernel(t, "transformPointNEON", transformPointNEON, transformPointGo, 3, 4)
+	checkKernel(t, "transformCoordNEON", transformCoordNEON, transformCoordGo, 3, 3)
+	checkKernel(t, "transformNormalNEON", transformNormalNEON, transformNormalGo, 3, 3)
+}
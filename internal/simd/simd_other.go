@@ -0,0 +1,29 @@
+//go:build (!amd64 && !arm64) || purego
+// +build !amd64,!arm64 purego
+
+package simd
+
+const (
+	enabled   = false
+	maxFloats = 1 << 28
+)
+
+func implementation() string {
+	return "go"
+}
+
+func transform(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32) {
+	transformGo(out, outStride, in, inStride, n, m)
+}
+
+func transformPoint(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32) {
+	transformPointGo(out, outStride, in, inStride, n, m)
+}
+
+func transformCoord(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32) {
+	transformCoordGo(out, outStride, in, inStride, n, m)
+}
+
+func transformNormal(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32) {
+	transformNormalGo(out, outStride, in, inStride, n, m)
+}
@@ -0,0 +1,149 @@
+package simd
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+type kernel func(out []float32, outStride int, in []float32, inStride, n int, m *[16]float32)
+
+func randomFloats(r *rand.Rand, n int) []float32 {
+	f := make([]float32, n)
+	for i := range f {
+		f[i] = float32(r.NormFloat64() * 100)
+	}
+	return f
+}
+
+func randomMatrix(r *rand.Rand) *[16]float32 {
+	var m [16]float32
+	copy(m[:], randomFloats(r, 16))
+	return &m
+}
+
+// checkKernel runs have and want on the same random inputs with various
+// strides and vector counts and requires bit-identical results.
+func checkKernel(t *testing.T, name string, have, want kernel, inSize, outSize int) {
+	t.Helper()
+	r := rand.New(rand.NewSource(1))
+	for _, stride := range []int{0, 1, 3, 5, 8} {
+		for n := 0; n <= 7; n++ {
+			m := randomMatrix(r)
+			inStride := inSize + stride
+			outStride := outSize + stride
+			in := randomFloats(r, n*inStride+inSize)
+			out1 := randomFloats(r, n*outStride+outSize)
+			out2 := append([]float32(nil), out1...)
+			have(out1, outStride, in, inStride, n, m)
+			want(out2, outStride, in, inStride, n, m)
+			checkBits(t, name, out1, out2)
+		}
+	}
+}
+
+// checkInPlace transforms a buffer in place and compares the result to a
+// transformation into a separate buffer.
+func checkInPlace(t *testing.T, name string, f kernel, size int) {
+	t.Helper()
+	r := rand.New(rand.NewSource(2))
+	const n, stride = 9, 8
+	m := randomMatrix(r)
+	buf := randomFloats(r, n*stride)
+	out := append([]float32(nil), buf...)
+	f(out, stride, buf, stride, n, m)
+	f(buf, stride, buf, stride, n, m)
+	checkBits(t, name+" in place", buf, out)
+}
+
+func checkBits(t *testing.T, name string, have, want []float32) {
+	t.Helper()
+	for i := range have {
+		if math.Float32bits(have[i]) != math.Float32bits(want[i]) {
+			t.Errorf("%s: element %d is %v but want %v", name, i, have[i], want[i])
+			return
+		}
+	}
+}
+
+func TestTransformsMatchGo(t *testing.T) {
+	checkKernel(t, "Transform", Transform, transformGo, 4, 4)
+	checkKernel(t, "TransformPoint", TransformPoint, transformPointGo, 3, 4)
+	checkKernel(t, "TransformCoord", TransformCoord, transformCoordGo, 3, 3)
+	checkKernel(t, "TransformNormal", TransformNormal, transformNormalGo, 3, 3)
+}
+
+func TestTransformsInPlace(t *testing.T) {
+	checkInPlace(t, "Transform", Transform, 4)
+	checkInPlace(t, "TransformPoint", TransformPoint, 4)
+	checkInPlace(t, "TransformCoord", TransformCoord, 3)
+	checkInPlace(t, "TransformNormal", TransformNormal, 3)
+}
+
+func TestTransformChecksBounds(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("short output did not panic")
+		}
+	}()
+	var m [16]float32
+	Transform(make([]float32, 7), 4, make([]float32, 8), 4, 2, &m)
+}
+
+func TestTransformRejectsNegativeStrides(t *testing.T) {
+	kernels := []struct {
+		name string
+		f    kernel
+	}{
+		{"Transform", Transform},
+		{"TransformPoint", TransformPoint},
+		{"TransformCoord", TransformCoord},
+		{"TransformNormal", TransformNormal},
+		{"transformGo", transformGo},
+		{"transformPointGo", transformPointGo},
+		{"transformCoordGo", transformCoordGo},
+		{"transformNormalGo", transformNormalGo},
+	}
+	var m [16]float32
+	for _, k := range kernels {
+		for _, strides := range [][2]int{{-1, 0}, {0, -1}, {-4, -4}} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("%s: strides %v did not panic", k.name, strides)
+					}
+				}()
+				buf := make([]float32, 16)
+				k.f(buf[8:], strides[0], buf[8:], strides[1], 3, &m)
+			}()
+		}
+		// A single vector is at index 0, whatever the stride.
+		k.f(make([]float32, 4), -1, make([]float32, 4), -1, 1, &m)
+	}
+}
+
+func TestTransformSemantics(t *testing.T) {
+	m := [16]float32{
+		1, 0, 0, 0,
+		0, 2, 0, 0,
+		0, 0, 4, 0,
+		10, 20, 30, 2,
+	}
+	out := make([]float32, 4)
+	TransformPoint(out, 4, []float32{1, 1, 1}, 3, 1, &m)
+	checkBits(t, "TransformPoint", out, []float32{11, 22, 34, 2})
+	TransformCoord(out, 3, []float32{1, 1, 1}, 3, 1, &m)
+	checkBits(t, "TransformCoord", out[:3], []float32{5.5, 11, 17})
+	TransformNormal(out, 3, []float32{1, 1, 1}, 3, 1, &m)
+	checkBits(t, "TransformNormal", out[:3], []float32{1, 2, 4})
+	Transform(out, 4, []float32{1, 1, 1, 0}, 4, 1, &m)
+	checkBits(t, "Transform", out, []float32{1, 2, 4, 0})
+}
+
+func TestImplementation(t *testing.T) {
+	impl := Implementation()
+	if Enabled == (impl == "go") {
+		t.Errorf("Enabled is %v but implementation is %q", Enabled, impl)
+	}
+	t.Log("using", impl)
+}
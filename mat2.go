@@ -1,43 +1,28 @@
 package d3dmath
 
-import "fmt"
+import rowmajor "github.com/gonutz/d3dmath/row_major/d3dmath"
 
 // Mat2 is a 2 by 2 matrix of float32s in row-major order.
 type Mat2 [4]float32
 
 // Add returns the sum of m + n.
 func (m Mat2) Add(n Mat2) Mat2 {
-	return Mat2{
-		m[0] + n[0], m[1] + n[1],
-		m[2] + n[2], m[3] + n[3],
-	}
+	return Mat2(rowmajor.Mat2(m).Add(rowmajor.Mat2(n)))
 }
 
 // Sub returns the difference of m - n.
 func (m Mat2) Sub(n Mat2) Mat2 {
-	return Mat2{
-		m[0] - n[0], m[1] - n[1],
-		m[2] - n[2], m[3] - n[3],
-	}
+	return Mat2(rowmajor.Mat2(m).Sub(rowmajor.Mat2(n)))
 }
 
 // Mul returns the product of m * n.
 func (m Mat2) Mul(n Mat2) Mat2 {
-	return Mat2{
-		m[0]*n[0] + m[1]*n[2],
-		m[0]*n[1] + m[1]*n[3],
-
-		m[2]*n[0] + m[3]*n[2],
-		m[2]*n[1] + m[3]*n[3],
-	}
+	return Mat2(rowmajor.Mat2(m).Mul(rowmajor.Mat2(n)))
 }
 
 // Identity2 returns the 2 by 2 identity matrix.
 func Identity2() Mat2 {
-	return Mat2{
-		1, 0,
-		0, 1,
-	}
+	return Mat2(rowmajor.Identity2())
 }
 
 // Mul2 returns the product of the given matrices.
@@ -50,23 +35,15 @@ func Mul2(m0 Mat2, m ...Mat2) Mat2 {
 
 // Transposed returns a transposed copy of m.
 func (m Mat2) Transposed() Mat2 {
-	return Mat2{
-		m[0], m[2],
-		m[1], m[3],
-	}
+	return Mat2(rowmajor.Mat2(m).Transposed())
 }
 
 // Homogeneous returns the homogeneous 3-dimensional equivalent of the
 // 2-dimensional matrix.
 func (m Mat2) Homogeneous() Mat3 {
-	return Mat3{
-		m[0], m[1], 0,
-		m[2], m[3], 0,
-		0, 0, 1,
-	}
+	return Mat3(rowmajor.Mat2(m).Homogeneous())
 }
 
 func (m Mat2) String() string {
-	return fmt.Sprintf(`%.2f %.2f
-%.2f %.2f`, m[0], m[1], m[2], m[3])
+	return rowmajor.Mat2(m).String()
 }
@@ -1,8 +1,9 @@
 package d3dmath
 
 import (
-	"fmt"
 	"math"
+
+	rowmajor "github.com/gonutz/d3dmath/row_major/d3dmath"
 )
 
 // Mat4 is a 4 by 4 matrix of float32s in row-major order.
@@ -10,43 +11,17 @@ type Mat4 [16]float32
 
 // Add returns the sum of m + n.
 func (m Mat4) Add(n Mat4) (sum Mat4) {
-	for i := range sum {
-		sum[i] = m[i] + n[i]
-	}
-	return
+	return Mat4(rowmajor.Mat4(m).Add(rowmajor.Mat4(n)))
 }
 
 // Sub returns the difference of m - n.
 func (m Mat4) Sub(n Mat4) (diff Mat4) {
-	for i := range diff {
-		diff[i] = m[i] - n[i]
-	}
-	return
+	return Mat4(rowmajor.Mat4(m).Sub(rowmajor.Mat4(n)))
 }
 
 // Mul returns the product of m * n.
 func (m Mat4) Mul(n Mat4) Mat4 {
-	return Mat4{
-		m[0]*n[0] + m[1]*n[4] + m[2]*n[8] + m[3]*n[12],
-		m[0]*n[1] + m[1]*n[5] + m[2]*n[9] + m[3]*n[13],
-		m[0]*n[2] + m[1]*n[6] + m[2]*n[10] + m[3]*n[14],
-		m[0]*n[3] + m[1]*n[7] + m[2]*n[11] + m[3]*n[15],
-
-		m[4]*n[0] + m[5]*n[4] + m[6]*n[8] + m[7]*n[12],
-		m[4]*n[1] + m[5]*n[5] + m[6]*n[9] + m[7]*n[13],
-		m[4]*n[2] + m[5]*n[6] + m[6]*n[10] + m[7]*n[14],
-		m[4]*n[3] + m[5]*n[7] + m[6]*n[11] + m[7]*n[15],
-
-		m[8]*n[0] + m[9]*n[4] + m[10]*n[8] + m[11]*n[12],
-		m[8]*n[1] + m[9]*n[5] + m[10]*n[9] + m[11]*n[13],
-		m[8]*n[2] + m[9]*n[6] + m[10]*n[10] + m[11]*n[14],
-		m[8]*n[3] + m[9]*n[7] + m[10]*n[11] + m[11]*n[15],
-
-		m[12]*n[0] + m[13]*n[4] + m[14]*n[8] + m[15]*n[12],
-		m[12]*n[1] + m[13]*n[5] + m[14]*n[9] + m[15]*n[13],
-		m[12]*n[2] + m[13]*n[6] + m[14]*n[10] + m[15]*n[14],
-		m[12]*n[3] + m[13]*n[7] + m[14]*n[11] + m[15]*n[15],
-	}
+	return Mat4(rowmajor.Mat4(m).Mul(rowmajor.Mat4(n)))
 }
 
 // Mul4 returns the product of the given matrices.
@@ -59,66 +34,70 @@ func Mul4(m0 Mat4, m ...Mat4) Mat4 {
 
 // Transposed returns a transposed copy of m.
 func (m Mat4) Transposed() Mat4 {
-	return Mat4{
-		m[0], m[4], m[8], m[12],
-		m[1], m[5], m[9], m[13],
-		m[2], m[6], m[10], m[14],
-		m[3], m[7], m[11], m[15],
-	}
+	return Mat4(rowmajor.Mat4(m).Transposed())
 }
 
 // Identity4 returns the 4 by 4 identity matrix.
 func Identity4() Mat4 {
-	return Mat4{
-		1, 0, 0, 0,
-		0, 1, 0, 0,
-		0, 0, 1, 0,
-		0, 0, 0, 1,
-	}
+	return Mat4(rowmajor.Identity4())
+}
+
+// Determinant returns the determinant of m.
+func (m Mat4) Determinant() float32 {
+	return rowmajor.Mat4(m).Determinant()
+}
+
+// Adjugate returns the adjugate of m, which is the transpose of its cofactor
+// matrix. Multiplying m with its adjugate gives the identity matrix scaled by
+// the determinant of m.
+func (m Mat4) Adjugate() Mat4 {
+	return Mat4(rowmajor.Mat4(m).Adjugate())
+}
+
+// Inverse returns the inverse of m, like D3DXMatrixInverse. If m is singular,
+// i.e. its determinant is 0, ok is false and the identity matrix is returned.
+func (m Mat4) Inverse() (inverse Mat4, ok bool) {
+	inv, ok := rowmajor.Mat4(m).Inverse()
+	return Mat4(inv), ok
+}
+
+// InverseAffine returns the inverse of m like Inverse, but faster. It only
+// works if m is an affine transformation, e.g. a combination of translations,
+// scales and rotations, where the last column is 0, 0, 0, 1. If m is singular,
+// ok is false and the identity matrix is returned.
+func (m Mat4) InverseAffine() (inverse Mat4, ok bool) {
+	inv, ok := rowmajor.Mat4(m).InverseAffine()
+	return Mat4(inv), ok
 }
 
 // Translate reutrns 4 by 4 matrix that, when multiplied with a homogeneous
 // 4-element 3D vector, moves the vector by the given amounts in x, y and z.
 func Translate(dx, dy, dz float32) Mat4 {
-	return Mat4{
-		1, 0, 0, 0,
-		0, 1, 0, 0,
-		0, 0, 1, 0,
-		dx, dy, dz, 1,
-	}
+	return Mat4(rowmajor.Translate(dx, dy, dz))
 }
 
 // TranslateV is the same as Translate, but it takes a Vec3 as its argument
 // instead of single x, y, z parameters.
 func TranslateV(v Vec3) Mat4 {
-	return Mat4{
-		1, 0, 0, 0,
-		0, 1, 0, 0,
-		0, 0, 1, 0,
-		v[0], v[1], v[2], 1,
-	}
+	return Mat4(rowmajor.TranslateV(rowmajor.Vec3(v)))
+}
+
+// ScaleUniform returns 4 by 4 matrix that, when multiplied with a homogeneous
+// 4-element 3D vector, scales the vector by the given factor in x, y and z.
+func ScaleUniform(s float32) Mat4 {
+	return Mat4(rowmajor.ScaleUniform(s))
 }
 
 // Scale reutrns 4 by 4 matrix that, when multiplied with a homogeneous
 // 4-element 3D vector, scales the vector by the given factors in x, y and z.
 func Scale(dx, dy, dz float32) Mat4 {
-	return Mat4{
-		dx, 0, 0, 0,
-		0, dy, 0, 0,
-		0, 0, dz, 0,
-		0, 0, 0, 1,
-	}
+	return Mat4(rowmajor.Scale(dx, dy, dz))
 }
 
 // ScaleV is the same as Scale, but it takes a Vec3 as its argument instead of
 // single x, y, z parameters.
 func ScaleV(v Vec3) Mat4 {
-	return Mat4{
-		v[0], 0, 0, 0,
-		0, v[1], 0, 0,
-		0, 0, v[2], 0,
-		0, 0, 0, 1,
-	}
+	return Mat4(rowmajor.ScaleV(rowmajor.Vec3(v)))
 }
 
 // RotateX reutrns 4 by 4 matrix that, when multiplied with a homogeneous
@@ -183,47 +162,23 @@ func RotateAbout(v Vec3, radians float32) Mat4 {
 
 // Ortho returns an orthographic projection matrix.
 func Ortho(left, right, bottom, top, near, far float32) Mat4 {
-	return Mat4{
-		2 / (right - left), 0, 0, 0,
-		0, 2 / (top - bottom), 0, 0,
-		0, 0, 2 / (far - near), 0,
-		(right + left) / (left - right), (top + bottom) / (bottom - top), (far + near) / (near - far), 1,
-	}
+	return Mat4(rowmajor.Ortho(left, right, bottom, top, near, far))
 }
 
 // Perspective returns an perspective projection matrix.
 func Perspective(fovRadians, aspect, near, far float32) Mat4 {
-	f := 1 / float32(math.Tan(float64(fovRadians)/2))
-	dz := far - near
-	return Mat4{
-		f / aspect, 0, 0, 0,
-		0, f, 0, 0,
-		0, 0, far / dz, 1,
-		0, 0, -near * far / dz, 0,
-	}
+	return Mat4(rowmajor.Perspective(fovRadians, aspect, near, far))
 }
 
 // LookAt returns a matrix that, when used for the camera, looks at target from
 // position pos. Since you can tilt your head in infinite ways looking from one
 // point at another, the up vector is used to specify which direction is up.
 func LookAt(pos, target, up Vec3) Mat4 {
-	z := target.Sub(pos).Normalized()
-	x := up.Cross(z).Normalized()
-	y := z.Cross(x)
-	return Mat4{
-		x[0], y[0], z[0], 0,
-		x[1], y[1], z[1], 0,
-		x[2], y[2], z[2], 0,
-		-x.Dot(pos), -y.Dot(pos), -z.Dot(pos), 1,
-	}
+	return Mat4(rowmajor.LookAt(rowmajor.Vec3(pos), rowmajor.Vec3(target), rowmajor.Vec3(up)))
 }
 
 func (m Mat4) String() string {
-	return fmt.Sprintf(`%.2f %.2f %.2f %.2f
-%.2f %.2f %.2f %.2f
-%.2f %.2f %.2f %.2f
-%.2f %.2f %.2f %.2f`, m[0], m[1], m[2], m[3], m[4], m[5], m[6], m[7], m[8],
-		m[9], m[10], m[11], m[12], m[13], m[14], m[15])
+	return rowmajor.Mat4(m).String()
 }
 
 // DecomposeAffineTransform decomposes the given matrix into scale, rotation and
@@ -231,27 +186,6 @@ func (m Mat4) String() string {
 // original matrix. See this forum post for reference:
 // https://math.stackexchange.com/questions/237369/given-this-transformation-matrix-how-do-i-decompose-it-into-translation-rotati
 func DecomposeAffineTransform(m Mat4) (scale, rotation, translation Mat4) {
-	translation = Mat4{
-		1, 0, 0, 0,
-		0, 1, 0, 0,
-		0, 0, 1, 0,
-		m[12], m[13], m[14], 1,
-	}
-	sx := Vec3{m[0], m[1], m[2]}.Norm()
-	sy := Vec3{m[4], m[5], m[6]}.Norm()
-	sz := Vec3{m[8], m[9], m[10]}.Norm()
-	scale = Mat4{
-		sx, 0, 0, 0,
-		0, sy, 0, 0,
-		0, 0, sz, 0,
-		0, 0, 0, 1,
-	}
-	fx, fy, fz := 1/sx, 1/sy, 1/sz
-	rotation = Mat4{
-		fx * m[0], fx * m[1], fx * m[2], 0,
-		fy * m[4], fy * m[5], fy * m[6], 0,
-		fz * m[8], fz * m[9], fz * m[10], 0,
-		0, 0, 0, 1,
-	}
-	return
+	s, r, t := rowmajor.DecomposeAffineTransform(rowmajor.Mat4(m))
+	return Mat4(s), Mat4(r), Mat4(t)
 }
@@ -1,6 +1,9 @@
 package d3dmath
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 func TestMat4MulMat4(t *testing.T) {
 	a := Mat4{
@@ -36,3 +39,146 @@ func TestDecomposeAffine(t *testing.T) {
 	// the transformations must match
 	checkFloats(t, m2[:], m[:]...)
 }
+
+func TestMat4Determinant(t *testing.T) {
+	m := Mat4{
+		3, 2, 0, 1,
+		4, 0, 1, 2,
+		3, 0, 2, 1,
+		9, 2, 3, 1,
+	}
+	checkFloat(t, m.Determinant(), 24)
+	checkFloat(t, Identity4().Determinant(), 1)
+	checkFloat(t, Scale(2, 3, 4).Determinant(), 24)
+}
+
+func TestMat4Adjugate(t *testing.T) {
+	m := Mat4{
+		3, 2, 0, 1,
+		4, 0, 1, 2,
+		3, 0, 2, 1,
+		9, 2, 3, 1,
+	}.Adjugate()
+	checkFloats(t, m[:],
+		-6, 6, -12, 6,
+		16, -12, 12, -4,
+		4, -12, 24, -4,
+		10, 6, 12, -10,
+	)
+}
+
+func TestMat4Inverse(t *testing.T) {
+	id := Identity4()
+	for _, m := range []Mat4{
+		{
+			3, 2, 0, 1,
+			4, 0, 1, 2,
+			3, 0, 2, 1,
+			9, 2, 3, 1,
+		},
+		LookAt(Vec3{1, 2, 3}, Vec3{-4, 5, 0}, Vec3{0, 1, 0}),
+		Perspective(1, 1.5, 0.1, 100),
+	} {
+		inv, ok := m.Inverse()
+		if !ok {
+			t.Errorf("matrix should be invertible:\n%v", m)
+		}
+		prod := m.Mul(inv)
+		checkFloatsNear(t, prod[:], id[:]...)
+		prod = inv.Mul(m)
+		checkFloatsNear(t, prod[:], id[:]...)
+	}
+}
+
+func TestMat4InverseOfSingularMatrix(t *testing.T) {
+	m := Mat4{
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+		9, 1, 2, 3,
+		4, 5, 6, 7,
+	}
+	checkFloat(t, m.Determinant(), 0)
+	inv, ok := m.Inverse()
+	if ok {
+		t.Error("singular matrix was inverted")
+	}
+	id := Identity4()
+	checkFloats(t, inv[:], id[:]...)
+}
+
+func TestMat4InverseAffine(t *testing.T) {
+	m := Mul4(
+		Scale(2, -3, 4),
+		RotateAbout(Vec3{3, -4, 5}, 2),
+		Translate(1, -2, 3),
+	)
+	inv, ok := m.InverseAffine()
+	if !ok {
+		t.Error("affine matrix should be invertible")
+	}
+	want, _ := m.Inverse()
+	checkFloatsNear(t, inv[:], want[:]...)
+	prod := m.Mul(inv)
+	id := Identity4()
+	checkFloatsNear(t, prod[:], id[:]...)
+
+	inv, ok = Scale(1, 0, 1).InverseAffine()
+	if ok {
+		t.Error("singular matrix was inverted")
+	}
+	checkFloats(t, inv[:], id[:]...)
+}
+
+func TestRotationsAreExactlyThoseOfV1(t *testing.T) {
+	// These are the rotations of version 1.0.0, which are not built on the
+	// turns based functions of the row-major package. Converting radians to
+	// turns and back would change the last bits of the results.
+	sincos := func(radians float32) (sin, cos float32) {
+		s, c := math.Sincos(float64(radians))
+		return float32(s), float32(c)
+	}
+	rotateX := func(radians float32) Mat4 {
+		sin, cos := sincos(radians)
+		return Mat4{1, 0, 0, 0, 0, cos, sin, 0, 0, -sin, cos, 0, 0, 0, 0, 1}
+	}
+	rotateY := func(radians float32) Mat4 {
+		sin, cos := sincos(radians)
+		return Mat4{cos, 0, -sin, 0, 0, 1, 0, 0, sin, 0, cos, 0, 0, 0, 0, 1}
+	}
+	rotateZ := func(radians float32) Mat4 {
+		sin, cos := sincos(radians)
+		return Mat4{cos, sin, 0, 0, -sin, cos, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1}
+	}
+	rotateAbout := func(v Vec3, radians float32) Mat4 {
+		sqLen := v[0]*v[0] + v[1]*v[1] + v[2]*v[2]
+		if sqLen < 0.99999 || sqLen > 1.00001 {
+			f := 1.0 / float32(math.Sqrt(float64(sqLen)))
+			v = Vec3{f * v[0], f * v[1], f * v[2]}
+		}
+		sin, cos := sincos(radians)
+		return Mat4{
+			cos + v[0]*v[0]*(1-cos), v[0]*v[1]*(1-cos) + v[2]*sin, v[0]*v[2]*(1-cos) - v[1]*sin, 0,
+			v[1]*v[0]*(1-cos) - v[2]*sin, cos + v[1]*v[1]*(1-cos), v[1]*v[2]*(1-cos) + v[0]*sin, 0,
+			v[2]*v[0]*(1-cos) + v[1]*sin, v[2]*v[1]*(1-cos) - v[0]*sin, cos + v[2]*v[2]*(1-cos), 0,
+			0, 0, 0, 1,
+		}
+	}
+	axes := []Vec3{{1, 0, 0}, {0, 1, 0}, {3, -4, 5}, {0.1, 0.2, -0.3}}
+	for i := -1000; i <= 1000; i++ {
+		radians := float32(i) * 0.0123
+		if have, want := RotateX(radians), rotateX(radians); have != want {
+			t.Fatalf("RotateX(%v) is\n%v\nbut want\n%v", radians, have, want)
+		}
+		if have, want := RotateY(radians), rotateY(radians); have != want {
+			t.Fatalf("RotateY(%v) is\n%v\nbut want\n%v", radians, have, want)
+		}
+		if have, want := RotateZ(radians), rotateZ(radians); have != want {
+			t.Fatalf("RotateZ(%v) is\n%v\nbut want\n%v", radians, have, want)
+		}
+		for _, axis := range axes {
+			if have, want := RotateAbout(axis, radians), rotateAbout(axis, radians); have != want {
+				t.Fatalf("RotateAbout(%v, %v) is\n%v\nbut want\n%v", axis, radians, have, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package d3dmath
+
+import (
+	"unsafe"
+
+	"github.com/gonutz/d3dmath/internal/simd"
+)
+
+// The functions in this file transform many vectors by the same matrix. They
+// read the matrix only once and are faster than calling MulMat in a loop. If
+// out and v have the same type, they may be the same slice to transform
+// vectors in place.
+//
+// The Strided versions work on interleaved vertex buffers. They transform n
+// vectors, where vector i starts at index i*vStride of v and its result at
+// index i*outStride of out. Only the vector elements are read and written, all
+// other data in the buffers stays untouched. For more than one vector, a
+// negative stride panics.
+//
+// On amd64 and arm64, the transforms use SIMD instructions, see package
+// github.com/gonutz/d3dmath/internal/simd.
+
+// TransformArray transforms the points in v, with w = 1, by m and writes the
+// homogeneous results to out, like D3DXVec3TransformArray. out must be at
+// least as long as v.
+func TransformArray(out []Vec4, v []Vec3, m Mat4) {
+	out = out[:len(v)]
+	if simd.Enabled {
+		simd.TransformPoint(vec4Floats(out), 4, vec3Floats(v), 3, len(v), m.rowMajor())
+		return
+	}
+	m00, m01, m02, m03 := m[0], m[1], m[2], m[3]
+	m10, m11, m12, m13 := m[4], m[5], m[6], m[7]
+	m20, m21, m22, m23 := m[8], m[9], m[10], m[11]
+	m30, m31, m32, m33 := m[12], m[13], m[14], m[15]
+	for i, p := range v {
+		x, y, z := p[0], p[1], p[2]
+		out[i] = Vec4{
+			x*m00 + y*m10 + z*m20 + m30,
+			x*m01 + y*m11 + z*m21 + m31,
+			x*m02 + y*m12 + z*m22 + m32,
+			x*m03 + y*m13 + z*m23 + m33,
+		}
+	}
+}
+
+// TransformCoordArray transforms the points in v, with w = 1, by m, divides
+// the results by their w and writes them to out, like
+// D3DXVec3TransformCoordArray. out must be at least as long as v.
+func TransformCoordArray(out, v []Vec3, m Mat4) {
+	out = out[:len(v)]
+	if simd.Enabled {
+		simd.TransformCoord(vec3Floats(out), 3, vec3Floats(v), 3, len(v), m.rowMajor())
+		return
+	}
+	m00, m01, m02, m03 := m[0], m[1], m[2], m[3]
+	m10, m11, m12, m13 := m[4], m[5], m[6], m[7]
+	m20, m21, m22, m23 := m[8], m[9], m[10], m[11]
+	m30, m31, m32, m33 := m[12], m[13], m[14], m[15]
+	for i, p := range v {
+		x, y, z := p[0], p[1], p[2]
+		f := 1 / (x*m03 + y*m13 + z*m23 + m33)
+		out[i] = Vec3{
+			f * (x*m00 + y*m10 + z*m20 + m30),
+			f * (x*m01 + y*m11 + z*m21 + m31),
+			f * (x*m02 + y*m12 + z*m22 + m32),
+		}
+	}
+}
+
+// TransformNormalArray transforms the directions in v by the upper left 3 by 3
+// part of m and writes the results to out, like D3DXVec3TransformNormalArray.
+// The translation in m is ignored. out must be at least as long as v.
+//
+// To keep normals perpendicular to surfaces under non-uniform scaling, pass
+// the inverse transpose of the matrix, see Mat4.NormalMatrix.
+func TransformNormalArray(out, v []Vec3, m Mat4) {
+	out = out[:len(v)]
+	if simd.Enabled {
+		simd.TransformNormal(vec3Floats(out), 3, vec3Floats(v), 3, len(v), m.rowMajor())
+		return
+	}
+	m00, m01, m02 := m[0], m[1], m[2]
+	m10, m11, m12 := m[4], m[5], m[6]
+	m20, m21, m22 := m[8], m[9], m[10]
+	for i, p := range v {
+		x, y, z := p[0], p[1], p[2]
+		out[i] = Vec3{
+			x*m00 + y*m10 + z*m20,
+			x*m01 + y*m11 + z*m21,
+			x*m02 + y*m12 + z*m22,
+		}
+	}
+}
+
+// TransformVec4Array transforms the vectors in v by m and writes the results
+// to out, like D3DXVec4TransformArray. out must be at least as long as v.
+func TransformVec4Array(out, v []Vec4, m Mat4) {
+	out = out[:len(v)]
+	if simd.Enabled {
+		simd.Transform(vec4Floats(out), 4, vec4Floats(v), 4, len(v), m.rowMajor())
+		return
+	}
+	m00, m01, m02, m03 := m[0], m[1], m[2], m[3]
+	m10, m11, m12, m13 := m[4], m[5], m[6], m[7]
+	m20, m21, m22, m23 := m[8], m[9], m[10], m[11]
+	m30, m31, m32, m33 := m[12], m[13], m[14], m[15]
+	for i, p := range v {
+		x, y, z, w := p[0], p[1], p[2], p[3]
+		out[i] = Vec4{
+			x*m00 + y*m10 + z*m20 + w*m30,
+			x*m01 + y*m11 + z*m21 + w*m31,
+			x*m02 + y*m12 + z*m22 + w*m32,
+			x*m03 + y*m13 + z*m23 + w*m33,
+		}
+	}
+}
+
+// TransformStrided is TransformArray for n points of 3 elements in v, writing
+// 4 elements for each result to out.
+func TransformStrided(out []float32, outStride int, v []float32, vStride, n int, m Mat4) {
+	if simd.Enabled {
+		simd.TransformPoint(out, outStride, v, vStride, n, m.rowMajor())
+		return
+	}
+	m00, m01, m02, m03 := m[0], m[1], m[2], m[3]
+	m10, m11, m12, m13 := m[4], m[5], m[6], m[7]
+	m20, m21, m22, m23 := m[8], m[9], m[10], m[11]
+	m30, m31, m32, m33 := m[12], m[13], m[14], m[15]
+	for i := 0; i < n; i++ {
+		p := v[i*vStride : i*vStride+3]
+		o := out[i*outStride : i*outStride+4]
+		x, y, z := p[0], p[1], p[2]
+		o[0] = x*m00 + y*m10 + z*m20 + m30
+		o[1] = x*m01 + y*m11 + z*m21 + m31
+		o[2] = x*m02 + y*m12 + z*m22 + m32
+		o[3] = x*m03 + y*m13 + z*m23 + m33
+	}
+}
+
+// TransformCoordStrided is TransformCoordArray for n points of 3 elements in v,
+// writing 3 elements for each result to out.
+func TransformCoordStrided(out []float32, outStride int, v []float32, vStride, n int, m Mat4) {
+	if simd.Enabled {
+		simd.TransformCoord(out, outStride, v, vStride, n, m.rowMajor())
+		return
+	}
+	m00, m01, m02, m03 := m[0], m[1], m[2], m[3]
+	m10, m11, m12, m13 := m[4], m[5], m[6], m[7]
+	m20, m21, m22, m23 := m[8], m[9], m[10], m[11]
+	m30, m31, m32, m33 := m[12], m[13], m[14], m[15]
+	for i := 0; i < n; i++ {
+		p := v[i*vStride : i*vStride+3]
+		o := out[i*outStride : i*outStride+3]
+		x, y, z := p[0], p[1], p[2]
+		f := 1 / (x*m03 + y*m13 + z*m23 + m33)
+		o[0] = f * (x*m00 + y*m10 + z*m20 + m30)
+		o[1] = f * (x*m01 + y*m11 + z*m21 + m31)
+		o[2] = f * (x*m02 + y*m12 + z*m22 + m32)
+	}
+}
+
+// TransformNormalStrided is TransformNormalArray for n directions of 3
+// elements in v, writing 3 elements for each result to out.
+func TransformNormalStrided(out []float32, outStride int, v []float32, vStride, n int, m Mat4) {
+	if simd.Enabled {
+		simd.TransformNormal(out, outStride, v, vStride, n, m.rowMajor())
+		return
+	}
+	m00, m01, m02 := m[0], m[1], m[2]
+	m10, m11, m12 := m[4], m[5], m[6]
+	m20, m21, m22 := m[8], m[9], m[10]
+	for i := 0; i < n; i++ {
+		p := v[i*vStride : i*vStride+3]
+		o := out[i*outStride : i*outStride+3]
+		x, y, z := p[0], p[1], p[2]
+		o[0] = x*m00 + y*m10 + z*m20
+		o[1] = x*m01 + y*m11 + z*m21
+		o[2] = x*m02 + y*m12 + z*m22
+	}
+}
+
+func vec3Floats(v []Vec3) []float32 {
+	if len(v) == 0 {
+		return nil
+	}
+	return simd.Floats(unsafe.Pointer(&v[0]), 3*len(v))
+}
+
+func vec4Floats(v []Vec4) []float32 {
+	if len(v) == 0 {
+		return nil
+	}
+	return simd.Floats(unsafe.Pointer(&v[0]), 4*len(v))
+}
@@ -0,0 +1,183 @@
+package d3dmath
+
+import "testing"
+
+func batchMatrix() Mat4 {
+	return Mul4(
+		Scale(2, 3, 4),
+		RotateRightHandAbout(Vec3{1, 2, 3}, 0.2),
+		Translate(1, -2, 3),
+		PerspectiveFovLH(1, 1.5, 0.5, 100),
+	)
+}
+
+func batchPoints(n int) []Vec3 {
+	v := make([]Vec3, n)
+	for i := range v {
+		f := float32(i)
+		v[i] = Vec3{f, 2*f - 5, 10 + f/3}
+	}
+	return v
+}
+
+func TestTransformArray(t *testing.T) {
+	m := batchMatrix()
+	v := batchPoints(10)
+	out := make([]Vec4, len(v))
+	TransformArray(out, v, m)
+	for i := range v {
+		want := v[i].Homogeneous().MulMat(m)
+		checkFloatsNear(t, out[i][:], want[:]...)
+	}
+}
+
+func TestTransformCoordArray(t *testing.T) {
+	m := batchMatrix()
+	v := batchPoints(10)
+	out := make([]Vec3, len(v))
+	TransformCoordArray(out, v, m)
+	for i := range v {
+		want := v[i].Homogeneous().MulMat(m).ByW()
+		checkFloatsNear(t, out[i][:], want[:]...)
+	}
+
+	// Transform in place.
+	TransformCoordArray(v, v, m)
+	for i := range v {
+		checkFloats(t, v[i][:], out[i][:]...)
+	}
+}
+
+func TestTransformNormalArray(t *testing.T) {
+	m := batchMatrix()
+	v := batchPoints(10)
+	out := make([]Vec3, len(v))
+	TransformNormalArray(out, v, m)
+	for i := range v {
+		want := v[i].Homogeneous()
+		want[3] = 0
+		want = want.MulMat(m)
+		checkFloatsNear(t, out[i][:], want[0], want[1], want[2])
+	}
+}
+
+func TestTransformVec4Array(t *testing.T) {
+	m := batchMatrix()
+	v := []Vec4{{1, 2, 3, 1}, {-1, 0.5, 2, 0}, {4, 5, 6, 2}}
+	out := make([]Vec4, len(v))
+	TransformVec4Array(out, v, m)
+	for i := range v {
+		want := v[i].MulMat(m)
+		checkFloatsNear(t, out[i][:], want[:]...)
+	}
+}
+
+func TestTransformArrayNeedsLongEnoughOutput(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("short output slice did not panic")
+		}
+	}()
+	TransformCoordArray(make([]Vec3, 1), make([]Vec3, 2), Identity4())
+}
+
+func TestTransformStrided(t *testing.T) {
+	m := batchMatrix()
+	points := batchPoints(5)
+	// An interleaved vertex buffer with position, normal and texture
+	// coordinates.
+	const stride = 8
+	var buf []float32
+	for i, p := range points {
+		f := float32(i)
+		buf = append(buf, p[0], p[1], p[2], 0, 1, 0, f, -f)
+	}
+	orig := append([]float32(nil), buf...)
+
+	out := make([]float32, 4*len(points))
+	TransformStrided(out, 4, buf, stride, len(points), m)
+	for i, p := range points {
+		want := p.Homogeneous().MulMat(m)
+		checkFloatsNear(t, out[4*i:4*i+4], want[:]...)
+	}
+
+	TransformNormalStrided(buf[3:], stride, buf[3:], stride, len(points), m)
+	TransformCoordStrided(buf, stride, buf, stride, len(points), m)
+	for i, p := range points {
+		v := buf[i*stride : (i+1)*stride]
+		pos := p.Homogeneous().MulMat(m).ByW()
+		checkFloatsNear(t, v[:3], pos[:]...)
+		normal := Vec4{0, 1, 0, 0}.MulMat(m)
+		checkFloatsNear(t, v[3:6], normal[:3]...)
+		// The texture coordinates are untouched.
+		checkFloats(t, v[6:], orig[i*stride+6:(i+1)*stride]...)
+	}
+}
+
+const benchmarkVertices = 10000
+
+func BenchmarkMulMatLoop(b *testing.B) {
+	m := batchMatrix()
+	v := batchPoints(benchmarkVertices)
+	out := make([]Vec3, len(v))
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for i := range v {
+			out[i] = v[i].Homogeneous().MulMat(m).ByW()
+		}
+	}
+}
+
+func BenchmarkTransformCoordArray(b *testing.B) {
+	m := batchMatrix()
+	v := batchPoints(benchmarkVertices)
+	out := make([]Vec3, len(v))
+	benchmarkSIMD(b, func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			TransformCoordArray(out, v, m)
+		}
+	})
+}
+
+func BenchmarkVec4MulMatLoop(b *testing.B) {
+	m := batchMatrix()
+	v := make([]Vec4, benchmarkVertices)
+	for i, p := range batchPoints(benchmarkVertices) {
+		v[i] = p.Homogeneous()
+	}
+	out := make([]Vec4, len(v))
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for i := range v {
+			out[i] = v[i].MulMat(m)
+		}
+	}
+}
+
+func BenchmarkTransformVec4Array(b *testing.B) {
+	m := batchMatrix()
+	v := make([]Vec4, benchmarkVertices)
+	for i, p := range batchPoints(benchmarkVertices) {
+		v[i] = p.Homogeneous()
+	}
+	out := make([]Vec4, len(v))
+	benchmarkSIMD(b, func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			TransformVec4Array(out, v, m)
+		}
+	})
+}
+
+func BenchmarkTransformCoordStrided(b *testing.B) {
+	m := batchMatrix()
+	const stride = 8
+	buf := make([]float32, stride*benchmarkVertices)
+	for i, p := range batchPoints(benchmarkVertices) {
+		copy(buf[i*stride:], p[:])
+	}
+	benchmarkSIMD(b, func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			TransformCoordStrided(buf, stride, buf, stride, benchmarkVertices, m)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package d3dmath
+
+// AABB is an axis-aligned bounding box that contains all points between Min
+// and Max.
+type AABB struct {
+	Min Vec3
+	Max Vec3
+}
+
+// Center returns the point in the middle of b.
+func (b AABB) Center() Vec3 {
+	return b.Min.Add(b.Max).MulScalar(0.5)
+}
+
+// HalfSize returns half the extent of b in x, y and z.
+func (b AABB) HalfSize() Vec3 {
+	return b.Max.Sub(b.Min).MulScalar(0.5)
+}
+
+// ContainsPoint returns true if p lies on or in b.
+func (b AABB) ContainsPoint(p Vec3) bool {
+	return p[0] >= b.Min[0] && p[0] <= b.Max[0] &&
+		p[1] >= b.Min[1] && p[1] <= b.Max[1] &&
+		p[2] >= b.Min[2] && p[2] <= b.Max[2]
+}
+
+// ClosestPoint returns the point on or in b that is closest to p.
+func (b AABB) ClosestPoint(p Vec3) Vec3 {
+	for i := range p {
+		if p[i] < b.Min[i] {
+			p[i] = b.Min[i]
+		}
+		if p[i] > b.Max[i] {
+			p[i] = b.Max[i]
+		}
+	}
+	return p
+}
+
+// IntersectsAABB returns true if b and c overlap or touch.
+func (b AABB) IntersectsAABB(c AABB) bool {
+	return b.Min[0] <= c.Max[0] && b.Max[0] >= c.Min[0] &&
+		b.Min[1] <= c.Max[1] && b.Max[1] >= c.Min[1] &&
+		b.Min[2] <= c.Max[2] && b.Max[2] >= c.Min[2]
+}
+
+// IntersectsSphere returns true if b and s overlap or touch.
+func (b AABB) IntersectsSphere(s Sphere) bool {
+	return s.IntersectsAABB(b)
+}
+
+// OBB is an oriented bounding box. It is centered at Center and extends by
+// HalfSize[i] in both directions along Axes[i]. The axes must be orthogonal
+// and have length 1.
+type OBB struct {
+	Center   Vec3
+	Axes     [3]Vec3
+	HalfSize Vec3
+}
+
+// OBBFromAABB returns the oriented box that results from transforming box b by
+// the affine matrix m. m may translate, rotate and scale but not shear.
+func OBBFromAABB(b AABB, m Mat4) OBB {
+	var o OBB
+	o.Center = b.Center().Homogeneous().MulMat(m).DropW()
+	half := b.HalfSize()
+	for i := range o.Axes {
+		var v Vec4
+		v[i] = 1
+		axis := v.MulMat(m).DropW()
+		length := axis.Norm()
+		if length != 0 {
+			o.Axes[i] = axis.MulScalar(1 / length)
+		}
+		o.HalfSize[i] = half[i] * length
+	}
+	return o
+}
+
+// ContainsPoint returns true if p lies on or in b.
+func (b OBB) ContainsPoint(p Vec3) bool {
+	d := p.Sub(b.Center)
+	for i, axis := range b.Axes {
+		dist := d.Dot(axis)
+		if dist < -b.HalfSize[i] || dist > b.HalfSize[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,62 @@
+package d3dmath
+
+import "testing"
+
+func TestAABBIntersectsAABB(t *testing.T) {
+	b := AABB{Min: Vec3{0, 0, 0}, Max: Vec3{2, 2, 2}}
+	for _, c := range []AABB{
+		{Min: Vec3{1, 1, 1}, Max: Vec3{3, 3, 3}},
+		{Min: Vec3{2, 0, 0}, Max: Vec3{3, 1, 1}},
+		{Min: Vec3{-1, -1, -1}, Max: Vec3{3, 3, 3}},
+	} {
+		if !b.IntersectsAABB(c) || !c.IntersectsAABB(b) {
+			t.Errorf("%v and %v should intersect", b, c)
+		}
+	}
+	for _, c := range []AABB{
+		{Min: Vec3{3, 0, 0}, Max: Vec3{4, 2, 2}},
+		{Min: Vec3{0, -2, 0}, Max: Vec3{2, -1, 2}},
+		{Min: Vec3{0, 0, 2.5}, Max: Vec3{2, 2, 3}},
+	} {
+		if b.IntersectsAABB(c) || c.IntersectsAABB(b) {
+			t.Errorf("%v and %v should not intersect", b, c)
+		}
+	}
+}
+
+func TestSphereIntersectsAABB(t *testing.T) {
+	b := AABB{Min: Vec3{0, 0, 0}, Max: Vec3{2, 2, 2}}
+	for _, s := range []Sphere{
+		{Center: Vec3{1, 1, 1}, Radius: 0.1},
+		{Center: Vec3{3, 1, 1}, Radius: 1},
+		{Center: Vec3{3, 3, 2}, Radius: 1.5},
+	} {
+		if !s.IntersectsAABB(b) || !b.IntersectsSphere(s) {
+			t.Errorf("%v and %v should intersect", s, b)
+		}
+	}
+	for _, s := range []Sphere{
+		{Center: Vec3{3.5, 1, 1}, Radius: 1},
+		{Center: Vec3{3, 3, 3}, Radius: 1.5},
+	} {
+		if s.IntersectsAABB(b) || b.IntersectsSphere(s) {
+			t.Errorf("%v and %v should not intersect", s, b)
+		}
+	}
+}
+
+func TestAABBCenterAndHalfSize(t *testing.T) {
+	b := AABB{Min: Vec3{1, 2, 3}, Max: Vec3{3, 6, 11}}
+	c := b.Center()
+	checkFloats(t, c[:], 2, 4, 7)
+	h := b.HalfSize()
+	checkFloats(t, h[:], 1, 2, 4)
+}
+
+func TestAABBClosestPoint(t *testing.T) {
+	b := AABB{Min: Vec3{0, 0, 0}, Max: Vec3{2, 2, 2}}
+	p := b.ClosestPoint(Vec3{-1, 1, 5})
+	checkFloats(t, p[:], 0, 1, 2)
+	p = b.ClosestPoint(Vec3{1, 1, 1})
+	checkFloats(t, p[:], 1, 1, 1)
+}
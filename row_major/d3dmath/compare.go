@@ -0,0 +1,219 @@
+package d3dmath
+
+import "math"
+
+// ApproxEqual reports whether a and b differ by at most epsilon. For values
+// larger than 1 the tolerance grows with the values, so epsilon is an
+// absolute tolerance near 0 and a relative tolerance for large numbers.
+func ApproxEqual(a, b, epsilon float32) bool {
+	if a == b {
+		return true
+	}
+	scale := float32(1)
+	if abs(a) > scale {
+		scale = abs(a)
+	}
+	if abs(b) > scale {
+		scale = abs(b)
+	}
+	return abs(a-b) <= epsilon*scale
+}
+
+// ULPDistance returns the number of representable floats between a and b,
+// which is 0 if they are equal and 1 if they are neighbors. Positive and
+// negative zero have distance 0. If a or b is NaN, the distance is
+// math.MaxUint64.
+func ULPDistance(a, b float32) uint64 {
+	if a != a || b != b {
+		return math.MaxUint64
+	}
+	ka, kb := ulpKey(a), ulpKey(b)
+	if ka > kb {
+		return ka - kb
+	}
+	return kb - ka
+}
+
+func approxEqualFloats(a, b []float32, epsilon float32) bool {
+	for i := range a {
+		if !ApproxEqual(a[i], b[i], epsilon) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalFloatsULP(a, b []float32, maxULP uint64) bool {
+	for i := range a {
+		if ULPDistance(a[i], b[i]) > maxULP {
+			return false
+		}
+	}
+	return true
+}
+
+// ApproxEqual reports whether all elements of v and w are equal within
+// epsilon, see function ApproxEqual.
+func (v Vec2) ApproxEqual(w Vec2, epsilon float32) bool {
+	return approxEqualFloats(v[:], w[:], epsilon)
+}
+
+// EqualULP reports whether all elements of v and w are at most maxULP
+// representable floats apart, see ULPDistance.
+func (v Vec2) EqualULP(w Vec2, maxULP uint64) bool {
+	return equalFloatsULP(v[:], w[:], maxULP)
+}
+
+// ApproxEqual reports whether all elements of v and w are equal within
+// epsilon, see function ApproxEqual.
+func (v Vec3) ApproxEqual(w Vec3, epsilon float32) bool {
+	return approxEqualFloats(v[:], w[:], epsilon)
+}
+
+// EqualULP reports whether all elements of v and w are at most maxULP
+// representable floats apart, see ULPDistance.
+func (v Vec3) EqualULP(w Vec3, maxULP uint64) bool {
+	return equalFloatsULP(v[:], w[:], maxULP)
+}
+
+// ApproxEqual reports whether all elements of v and w are equal within
+// epsilon, see function ApproxEqual.
+func (v Vec4) ApproxEqual(w Vec4, epsilon float32) bool {
+	return approxEqualFloats(v[:], w[:], epsilon)
+}
+
+// EqualULP reports whether all elements of v and w are at most maxULP
+// representable floats apart, see ULPDistance.
+func (v Vec4) EqualULP(w Vec4, maxULP uint64) bool {
+	return equalFloatsULP(v[:], w[:], maxULP)
+}
+
+// ApproxEqual reports whether all elements of q and r are equal within
+// epsilon, see function ApproxEqual. Note that q and -q represent the same
+// rotation but are not equal.
+func (q Quat) ApproxEqual(r Quat, epsilon float32) bool {
+	return approxEqualFloats(q[:], r[:], epsilon)
+}
+
+// EqualULP reports whether all elements of q and r are at most maxULP
+// representable floats apart, see ULPDistance.
+func (q Quat) EqualULP(r Quat, maxULP uint64) bool {
+	return equalFloatsULP(q[:], r[:], maxULP)
+}
+
+// ApproxEqual reports whether all elements of p and q are equal within
+// epsilon, see function ApproxEqual. Note that planes with scaled
+// coefficients are the same plane but are not equal.
+func (p Plane) ApproxEqual(q Plane, epsilon float32) bool {
+	return approxEqualFloats(p[:], q[:], epsilon)
+}
+
+// EqualULP reports whether all elements of p and q are at most maxULP
+// representable floats apart, see ULPDistance.
+func (p Plane) EqualULP(q Plane, maxULP uint64) bool {
+	return equalFloatsULP(p[:], q[:], maxULP)
+}
+
+// ApproxEqual reports whether all elements of m and n are equal within
+// epsilon, see function ApproxEqual.
+func (m Mat2) ApproxEqual(n Mat2, epsilon float32) bool {
+	return approxEqualFloats(m[:], n[:], epsilon)
+}
+
+// EqualULP reports whether all elements of m and n are at most maxULP
+// representable floats apart, see ULPDistance.
+func (m Mat2) EqualULP(n Mat2, maxULP uint64) bool {
+	return equalFloatsULP(m[:], n[:], maxULP)
+}
+
+// IsIdentity reports whether m is the identity matrix within epsilon.
+func (m Mat2) IsIdentity(epsilon float32) bool {
+	return m.ApproxEqual(Identity2(), epsilon)
+}
+
+// IsOrthonormal reports whether the rows of m, and thus its columns, are
+// perpendicular unit vectors within epsilon. Such a matrix rotates and
+// possibly mirrors, but does not scale or shear.
+func (m Mat2) IsOrthonormal(epsilon float32) bool {
+	return m.Mul(m.Transposed()).IsIdentity(epsilon)
+}
+
+// ApproxEqual reports whether all elements of m and n are equal within
+// epsilon, see function ApproxEqual.
+func (m Mat3) ApproxEqual(n Mat3, epsilon float32) bool {
+	return approxEqualFloats(m[:], n[:], epsilon)
+}
+
+// EqualULP reports whether all elements of m and n are at most maxULP
+// representable floats apart, see ULPDistance.
+func (m Mat3) EqualULP(n Mat3, maxULP uint64) bool {
+	return equalFloatsULP(m[:], n[:], maxULP)
+}
+
+// IsIdentity reports whether m is the identity matrix within epsilon.
+func (m Mat3) IsIdentity(epsilon float32) bool {
+	return m.ApproxEqual(Identity3(), epsilon)
+}
+
+// IsOrthonormal reports whether the rows of m, and thus its columns, are
+// perpendicular unit vectors within epsilon. Such a matrix rotates and
+// possibly mirrors, but does not scale or shear.
+func (m Mat3) IsOrthonormal(epsilon float32) bool {
+	return m.Mul(m.Transposed()).IsIdentity(epsilon)
+}
+
+// ApproxEqual reports whether all elements of m and n are equal within
+// epsilon, see function ApproxEqual.
+func (m Mat2x3) ApproxEqual(n Mat2x3, epsilon float32) bool {
+	return approxEqualFloats(m[:], n[:], epsilon)
+}
+
+// EqualULP reports whether all elements of m and n are at most maxULP
+// representable floats apart, see ULPDistance.
+func (m Mat2x3) EqualULP(n Mat2x3, maxULP uint64) bool {
+	return equalFloatsULP(m[:], n[:], maxULP)
+}
+
+// IsIdentity reports whether m is the identity matrix within epsilon.
+func (m Mat2x3) IsIdentity(epsilon float32) bool {
+	return m.ApproxEqual(Identity2x3(), epsilon)
+}
+
+// ApproxEqual reports whether all elements of m and n are equal within
+// epsilon, see function ApproxEqual.
+func (m Mat4) ApproxEqual(n Mat4, epsilon float32) bool {
+	return approxEqualFloats(m[:], n[:], epsilon)
+}
+
+// EqualULP reports whether all elements of m and n are at most maxULP
+// representable floats apart, see ULPDistance.
+func (m Mat4) EqualULP(n Mat4, maxULP uint64) bool {
+	return equalFloatsULP(m[:], n[:], maxULP)
+}
+
+// IsIdentity reports whether m is the identity matrix within epsilon, like
+// D3DXMatrixIsIdentity with a tolerance.
+func (m Mat4) IsIdentity(epsilon float32) bool {
+	return m.ApproxEqual(Identity4(), epsilon)
+}
+
+// IsOrthonormal reports whether the upper left 3 by 3 part of m is
+// orthonormal within epsilon, see Mat3.IsOrthonormal. The translation and
+// projection parts of m are not checked.
+func (m Mat4) IsOrthonormal(epsilon float32) bool {
+	return Mat3{
+		m[0], m[1], m[2],
+		m[4], m[5], m[6],
+		m[8], m[9], m[10],
+	}.IsOrthonormal(epsilon)
+}
+
+// IsAffine reports whether m has no projection part within epsilon, i.e. it
+// maps points with w = 1 to points with w = 1. Combinations of translation,
+// rotation, scaling and shearing are affine, perspective projections are not.
+func (m Mat4) IsAffine(epsilon float32) bool {
+	return ApproxEqual(m[3], 0, epsilon) &&
+		ApproxEqual(m[7], 0, epsilon) &&
+		ApproxEqual(m[11], 0, epsilon) &&
+		ApproxEqual(m[15], 1, epsilon)
+}
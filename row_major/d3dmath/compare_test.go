@@ -0,0 +1,188 @@
+package d3dmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestApproxEqual(t *testing.T) {
+	tests := []struct {
+		a, b, epsilon float32
+		want          bool
+	}{
+		{1, 1, 0, true},
+		{1, 1.000001, 1e-5, true},
+		{1, 1.1, 1e-5, false},
+		{0, 1e-6, 1e-5, true},
+		{0, -1e-6, 1e-5, true},
+		{0, 1e-4, 1e-5, false},
+		// Large numbers are compared relative to their size.
+		{1e6, 1e6 + 5, 1e-5, true},
+		{1e6, 1e6 + 50, 1e-5, false},
+		{-1e6, -1e6 - 5, 1e-5, true},
+	}
+	for _, test := range tests {
+		if have := ApproxEqual(test.a, test.b, test.epsilon); have != test.want {
+			t.Errorf("ApproxEqual(%v, %v, %v) is %v", test.a, test.b, test.epsilon, have)
+		}
+	}
+}
+
+func TestULPDistance(t *testing.T) {
+	tiny := math.Nextafter32(0, 1)
+	nan := float32(math.NaN())
+	tests := []struct {
+		a, b float32
+		want uint64
+	}{
+		{1, 1, 0},
+		{1, math.Nextafter32(1, 2), 1},
+		{math.Nextafter32(1, 2), 1, 1},
+		{-1, math.Nextafter32(-1, -2), 1},
+		{1, math.Nextafter32(math.Nextafter32(1, 0), 0), 2},
+		{0, float32(math.Copysign(0, -1)), 0},
+		{tiny, -tiny, 2},
+		{-tiny, 0, 1},
+		{1, 2, uint64(1 / epsilon())},
+		{nan, 1, math.MaxUint64},
+		{1, nan, math.MaxUint64},
+	}
+	for _, test := range tests {
+		if have := ULPDistance(test.a, test.b); have != test.want {
+			t.Errorf("ULPDistance(%v, %v) is %v but want %v", test.a, test.b, have, test.want)
+		}
+	}
+}
+
+func TestTypesApproxEqual(t *testing.T) {
+	next := math.Nextafter32(3, 4)
+	if !(Vec2{1, 3}).ApproxEqual(Vec2{1, 3.000001}, 1e-5) ||
+		(Vec2{1, 3}).ApproxEqual(Vec2{1, 3.1}, 1e-5) ||
+		!(Vec2{1, 3}).EqualULP(Vec2{1, next}, 1) ||
+		(Vec2{1, 3}).EqualULP(Vec2{1, next}, 0) {
+		t.Error("Vec2 comparison is wrong")
+	}
+	if !(Vec3{1, 2, 3}).ApproxEqual(Vec3{1, 2, 3.000001}, 1e-5) ||
+		(Vec3{1, 2, 3}).ApproxEqual(Vec3{1, 2, 3.1}, 1e-5) ||
+		!(Vec3{1, 2, 3}).EqualULP(Vec3{1, 2, next}, 1) ||
+		(Vec3{1, 2, 3}).EqualULP(Vec3{1, 2, next}, 0) {
+		t.Error("Vec3 comparison is wrong")
+	}
+	if !(Vec4{1, 2, 3, 4}).ApproxEqual(Vec4{1, 2, 3.000001, 4}, 1e-5) ||
+		(Vec4{1, 2, 3, 4}).ApproxEqual(Vec4{1, 2, 3.1, 4}, 1e-5) ||
+		!(Vec4{1, 2, 3, 4}).EqualULP(Vec4{1, 2, next, 4}, 1) ||
+		(Vec4{1, 2, 3, 4}).EqualULP(Vec4{1, 2, next, 4}, 0) {
+		t.Error("Vec4 comparison is wrong")
+	}
+	if !(Quat{1, 2, 3, 4}).ApproxEqual(Quat{1, 2, 3.000001, 4}, 1e-5) ||
+		(Quat{1, 2, 3, 4}).ApproxEqual(Quat{1, 2, 3.1, 4}, 1e-5) ||
+		!(Quat{1, 2, 3, 4}).EqualULP(Quat{1, 2, next, 4}, 1) {
+		t.Error("Quat comparison is wrong")
+	}
+	if !(Plane{1, 2, 3, 4}).ApproxEqual(Plane{1, 2, 3.000001, 4}, 1e-5) ||
+		(Plane{1, 2, 3, 4}).ApproxEqual(Plane{1, 2, 3.1, 4}, 1e-5) ||
+		!(Plane{1, 2, 3, 4}).EqualULP(Plane{1, 2, next, 4}, 1) {
+		t.Error("Plane comparison is wrong")
+	}
+	if !(Mat2{1, 2, 3, 4}).ApproxEqual(Mat2{1, 2, 3.000001, 4}, 1e-5) ||
+		(Mat2{1, 2, 3, 4}).ApproxEqual(Mat2{1, 2, 3.1, 4}, 1e-5) ||
+		!(Mat2{1, 2, 3, 4}).EqualULP(Mat2{1, 2, next, 4}, 1) {
+		t.Error("Mat2 comparison is wrong")
+	}
+	if !(Mat2x3{1, 2, 3, 4, 5, 6}).ApproxEqual(Mat2x3{1, 2, 3.000001, 4, 5, 6}, 1e-5) ||
+		(Mat2x3{1, 2, 3, 4, 5, 6}).ApproxEqual(Mat2x3{1, 2, 3.1, 4, 5, 6}, 1e-5) ||
+		!(Mat2x3{1, 2, 3, 4, 5, 6}).EqualULP(Mat2x3{1, 2, next, 4, 5, 6}, 1) {
+		t.Error("Mat2x3 comparison is wrong")
+	}
+	m3 := Mat3{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	n3 := m3
+	n3[2] = next
+	if !m3.ApproxEqual(n3, 1e-5) || !m3.EqualULP(n3, 1) || m3.EqualULP(n3, 0) {
+		t.Error("Mat3 comparison is wrong")
+	}
+	m4 := RotateLeftHandAbout(Vec3{1, 2, 3}, 0.1)
+	n4 := m4
+	n4[9] = math.Nextafter32(n4[9], 10)
+	if !m4.ApproxEqual(n4, 1e-5) || !m4.EqualULP(n4, 1) || m4.EqualULP(n4, 0) {
+		t.Error("Mat4 comparison is wrong")
+	}
+	n4[9] += 0.1
+	if m4.ApproxEqual(n4, 1e-5) {
+		t.Error("Mat4 comparison is wrong")
+	}
+}
+
+func TestIsIdentity(t *testing.T) {
+	if !Identity2().IsIdentity(0) || (Mat2{1, 0, 0.1, 1}).IsIdentity(1e-5) {
+		t.Error("Mat2 IsIdentity is wrong")
+	}
+	if !Identity3().IsIdentity(0) || (Mat3{2, 0, 0, 0, 1, 0, 0, 0, 1}).IsIdentity(1e-5) {
+		t.Error("Mat3 IsIdentity is wrong")
+	}
+	if !Identity2x3().IsIdentity(0) || Translate2D(0, 1).IsIdentity(1e-5) {
+		t.Error("Mat2x3 IsIdentity is wrong")
+	}
+	if !Identity4().IsIdentity(0) || Translate(1, 0, 0).IsIdentity(1e-5) {
+		t.Error("Mat4 IsIdentity is wrong")
+	}
+	// A full turn is the identity up to rounding errors.
+	if !RotateLeftHandAbout(Vec3{1, 2, 3}, 1).IsIdentity(1e-5) {
+		t.Error("a full turn is not the identity")
+	}
+}
+
+func TestIsOrthonormal(t *testing.T) {
+	rotation := RotateLeftHandAbout(Vec3{1, 2, 3}, 0.3)
+	tests := []struct {
+		name string
+		m    Mat4
+		want bool
+	}{
+		{"identity", Identity4(), true},
+		{"rotation", rotation, true},
+		{"rotation and translation", Mul4(rotation, Translate(1, 2, 3)), true},
+		{"mirror", Scale(1, -1, 1), true},
+		{"scale", Scale(1, 2, 1), false},
+		{"scaled rotation", Mul4(ScaleUniform(2), rotation), false},
+	}
+	for _, test := range tests {
+		if have := test.m.IsOrthonormal(1e-5); have != test.want {
+			t.Errorf("%s: IsOrthonormal is %v", test.name, have)
+		}
+	}
+
+	q := QuatRightHandAbout(Vec3{3, 1, 2}, 0.2)
+	if !q.ToMat3().IsOrthonormal(1e-5) {
+		t.Error("Mat3 rotation is not orthonormal")
+	}
+	if (Mat3{1, 1, 0, 0, 1, 0, 0, 0, 1}).IsOrthonormal(1e-5) {
+		t.Error("Mat3 shear is orthonormal")
+	}
+	if !(Mat2{0, 1, -1, 0}).IsOrthonormal(0) ||
+		(Mat2{1, 1, 0, 1}).IsOrthonormal(1e-5) {
+		t.Error("Mat2 IsOrthonormal is wrong")
+	}
+}
+
+func TestIsAffine(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Mat4
+		want bool
+	}{
+		{"identity", Identity4(), true},
+		{"translation", Translate(1, 2, 3), true},
+		{"transform", Mul4(
+			Scale(1, 2, 3),
+			RotateLeftHandAbout(Vec3{1, 2, 3}, 0.3),
+			Translate(1, 2, 3),
+		), true},
+		{"perspective", PerspectiveFovLH(1, 1.5, 0.5, 100), false},
+		{"orthographic", OrthoLH(4, 3, 0.5, 100), true},
+	}
+	for _, test := range tests {
+		if have := test.m.IsAffine(1e-5); have != test.want {
+			t.Errorf("%s: IsAffine is %v", test.name, have)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package d3dmath
+
+import "math"
+
+// The spherical and cylindrical coordinates use the y-axis as the up axis.
+// The azimuth is measured about the y-axis, 0 points along the positive
+// z-axis and 1/4 turn along the positive x-axis. The elevation is measured
+// from the x-z plane, 1/4 turn points along the positive y-axis. All angles
+// are in turns.
+
+// Vec2FromPolar returns the point at the given distance from the origin, in
+// the direction that is rotated by the given number of turns from the positive
+// x-axis towards the positive y-axis.
+func Vec2FromPolar(radius, turns float32) Vec2 {
+	sin, cos := math.Sincos(turnsToRadians(turns))
+	return Vec2{
+		float32(float64(radius) * cos),
+		float32(float64(radius) * sin),
+	}
+}
+
+// Polar returns the polar coordinates of v, see Vec2FromPolar. The turns lie
+// in the range [-1/2, 1/2]. For the zero vector they are 0.
+func (v Vec2) Polar() (radius, turns float32) {
+	rad := math.Atan2(float64(v[1]), float64(v[0]))
+	return v.Norm(), float32(rad * RadToTurns)
+}
+
+// Vec3FromSpherical returns the point at the given distance from the origin,
+// in the direction given by azimuth and elevation. This is the point
+// Vec3{0, 0, radius}, first rotated by elevation with RotateRightHandX and
+// then by azimuth with RotateLeftHandY.
+func Vec3FromSpherical(radius, azimuth, elevation float32) Vec3 {
+	sinAz, cosAz := math.Sincos(turnsToRadians(azimuth))
+	sinEl, cosEl := math.Sincos(turnsToRadians(elevation))
+	return Vec3{
+		float32(float64(radius) * cosEl * sinAz),
+		float32(float64(radius) * sinEl),
+		float32(float64(radius) * cosEl * cosAz),
+	}
+}
+
+// Spherical returns the spherical coordinates of v, see Vec3FromSpherical.
+// The azimuth lies in the range [-1/2, 1/2] and the elevation in
+// [-1/4, 1/4]. On the y-axis, the azimuth is 0.
+func (v Vec3) Spherical() (radius, azimuth, elevation float32) {
+	x, y, z := float64(v[0]), float64(v[1]), float64(v[2])
+	az := math.Atan2(x, z)
+	el := math.Atan2(y, math.Hypot(x, z))
+	return v.Norm(), float32(az * RadToTurns), float32(el * RadToTurns)
+}
+
+// Vec3FromCylindrical returns the point at the given distance from the y-axis,
+// in the direction given by azimuth, at the given height above the x-z plane.
+func Vec3FromCylindrical(radius, azimuth, height float32) Vec3 {
+	sin, cos := math.Sincos(turnsToRadians(azimuth))
+	return Vec3{
+		float32(float64(radius) * sin),
+		height,
+		float32(float64(radius) * cos),
+	}
+}
+
+// Cylindrical returns the cylindrical coordinates of v, see
+// Vec3FromCylindrical. The azimuth lies in the range [-1/2, 1/2]. On the
+// y-axis, it is 0.
+func (v Vec3) Cylindrical() (radius, azimuth, height float32) {
+	x, z := float64(v[0]), float64(v[2])
+	return float32(math.Hypot(x, z)), float32(math.Atan2(x, z) * RadToTurns), v[1]
+}
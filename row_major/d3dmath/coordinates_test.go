@@ -0,0 +1,53 @@
+package d3dmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPolar(t *testing.T) {
+	v := Vec2FromPolar(2, 0.25)
+	checkFloatsNear(t, v[:], 0, 2)
+	v = Vec2FromPolar(2, -0.125)
+	checkFloatsNear(t, v[:], math.Sqrt2, -math.Sqrt2)
+
+	radius, turns := Vec2{0, -3}.Polar()
+	checkFloatsNear(t, []float32{radius, turns}, 3, -0.25)
+	radius, turns = Vec2FromPolar(5, 0.4).Polar()
+	checkFloatsNear(t, []float32{radius, turns}, 5, 0.4)
+	radius, turns = Vec2{}.Polar()
+	checkFloats(t, []float32{radius, turns}, 0, 0)
+}
+
+func TestSpherical(t *testing.T) {
+	v := Vec3FromSpherical(2, 0, 0)
+	checkFloatsNear(t, v[:], 0, 0, 2)
+	v = Vec3FromSpherical(2, 0.25, 0)
+	checkFloatsNear(t, v[:], 2, 0, 0)
+	v = Vec3FromSpherical(2, 0.1, 0.25)
+	checkFloatsNear(t, v[:], 0, 2, 0)
+
+	v = Vec3FromSpherical(3, 0.1, 0.2)
+	rotate := Mul4(RotateRightHandX(0.2), RotateLeftHandY(0.1))
+	want := Vec3{0, 0, 3}.TransformCoord(rotate)
+	checkFloatsNear(t, v[:], want[:]...)
+
+	radius, azimuth, elevation := v.Spherical()
+	checkFloatsNear(t, []float32{radius, azimuth, elevation}, 3, 0.1, 0.2)
+	radius, azimuth, elevation = Vec3FromSpherical(1, -0.4, -0.15).Spherical()
+	checkFloatsNear(t, []float32{radius, azimuth, elevation}, 1, -0.4, -0.15)
+	radius, azimuth, elevation = Vec3{0, -2, 0}.Spherical()
+	checkFloats(t, []float32{radius, azimuth, elevation}, 2, 0, -0.25)
+}
+
+func TestCylindrical(t *testing.T) {
+	v := Vec3FromCylindrical(2, 0.25, 5)
+	checkFloatsNear(t, v[:], 2, 5, 0)
+	v = Vec3FromCylindrical(2, 0.5, -1)
+	checkFloatsNear(t, v[:], 0, -1, -2)
+
+	radius, azimuth, height := Vec3FromCylindrical(3, -0.3, 4).Cylindrical()
+	checkFloatsNear(t, []float32{radius, azimuth, height}, 3, -0.3, 4)
+	radius, azimuth, height = Vec3{0, 7, 0}.Cylindrical()
+	checkFloats(t, []float32{radius, azimuth, height}, 0, 0, 7)
+}
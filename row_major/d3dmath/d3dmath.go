@@ -1,12 +1,10 @@
-/*
-Package d3dmath provices vector and matrix functions for Direct3D. Vectors are
-row vectors and matrices are stored in row-major order.
-*/
 package d3dmath
 
 import (
 	"fmt"
 	"math"
+
+	"github.com/gonutz/d3dmath/internal/simd"
 )
 
 // These factors can be used to convert between turns (as used for the rotation
@@ -58,6 +56,53 @@ func (v Vec2) MulMat(m Mat2) Vec2 {
 	}
 }
 
+// Transform returns the product of v, extended to a homogeneous point with
+// z = 1, and matrix m.
+func (v Vec2) Transform(m Mat3) Vec3 {
+	return Vec3{
+		v[0]*m[0] + v[1]*m[3] + m[6],
+		v[0]*m[1] + v[1]*m[4] + m[7],
+		v[0]*m[2] + v[1]*m[5] + m[8],
+	}
+}
+
+// TransformCoord transforms the point v, with z = 1, by m and divides the
+// result by its z. This is the same as v.Homogeneous().MulMat(m).ByZ(), except
+// for a resulting z of 0, which ByZ ignores.
+func (v Vec2) TransformCoord(m Mat3) Vec2 {
+	f := 1 / (v[0]*m[2] + v[1]*m[5] + m[8])
+	return Vec2{
+		f * (v[0]*m[0] + v[1]*m[3] + m[6]),
+		f * (v[0]*m[1] + v[1]*m[4] + m[7]),
+	}
+}
+
+// TransformNormal transforms the direction v, with z = 0, by m. The
+// translation in m is ignored.
+func (v Vec2) TransformNormal(m Mat3) Vec2 {
+	return Vec2{
+		v[0]*m[0] + v[1]*m[3],
+		v[0]*m[1] + v[1]*m[4],
+	}
+}
+
+// TransformCoord2x3 returns the point v transformed by the affine matrix m.
+func (v Vec2) TransformCoord2x3(m Mat2x3) Vec2 {
+	return Vec2{
+		v[0]*m[0] + v[1]*m[1] + m[2],
+		v[0]*m[3] + v[1]*m[4] + m[5],
+	}
+}
+
+// TransformNormal2x3 returns the direction v transformed by the affine matrix
+// m. The translation in m is ignored.
+func (v Vec2) TransformNormal2x3(m Mat2x3) Vec2 {
+	return Vec2{
+		v[0]*m[0] + v[1]*m[1],
+		v[0]*m[3] + v[1]*m[4],
+	}
+}
+
 // SquareNorm returns the square of the length of v.
 func (v Vec2) SquareNorm() float32 {
 	return v[0]*v[0] + v[1]*v[1]
@@ -69,9 +114,13 @@ func (v Vec2) Norm() float32 {
 }
 
 // Normalized returns a copy of v with elements normalized so the returned
-// vector has length 1.
+// vector has length 1, or the zero vector if the length is 0.
 func (v Vec2) Normalized() Vec2 {
-	f := 1.0 / v.Norm()
+	norm := v.Norm()
+	if norm == 0 {
+		return Vec2{}
+	}
+	f := 1.0 / norm
 	return Vec2{f * v[0], f * v[1]}
 }
 
@@ -139,6 +188,42 @@ func (v Vec3) MulMat(m Mat3) Vec3 {
 	}
 }
 
+// Transform returns the product of v, extended to a homogeneous point with
+// w = 1, and matrix m, like D3DXVec3Transform.
+func (v Vec3) Transform(m Mat4) Vec4 {
+	return Vec4{
+		v[0]*m[0] + v[1]*m[4] + v[2]*m[8] + m[12],
+		v[0]*m[1] + v[1]*m[5] + v[2]*m[9] + m[13],
+		v[0]*m[2] + v[1]*m[6] + v[2]*m[10] + m[14],
+		v[0]*m[3] + v[1]*m[7] + v[2]*m[11] + m[15],
+	}
+}
+
+// TransformCoord transforms the point v, with w = 1, by m and divides the
+// result by its w, like D3DXVec3TransformCoord. This is the same as
+// v.Homogeneous().MulMat(m).ByW(), except for a resulting w of 0, which ByW
+// ignores.
+func (v Vec3) TransformCoord(m Mat4) Vec3 {
+	f := 1 / (v[0]*m[3] + v[1]*m[7] + v[2]*m[11] + m[15])
+	return Vec3{
+		f * (v[0]*m[0] + v[1]*m[4] + v[2]*m[8] + m[12]),
+		f * (v[0]*m[1] + v[1]*m[5] + v[2]*m[9] + m[13]),
+		f * (v[0]*m[2] + v[1]*m[6] + v[2]*m[10] + m[14]),
+	}
+}
+
+// TransformNormal transforms the direction v, with w = 0, by m, like
+// D3DXVec3TransformNormal. The translation in m is ignored. For surface normals
+// under non-uniform scaling, use v.MulMat(normal) with the matrix returned by
+// Mat4.NormalMatrix instead.
+func (v Vec3) TransformNormal(m Mat4) Vec3 {
+	return Vec3{
+		v[0]*m[0] + v[1]*m[4] + v[2]*m[8],
+		v[0]*m[1] + v[1]*m[5] + v[2]*m[9],
+		v[0]*m[2] + v[1]*m[6] + v[2]*m[10],
+	}
+}
+
 // SquareNorm returns the square of the length of v.
 func (v Vec3) SquareNorm() float32 {
 	return v[0]*v[0] + v[1]*v[1] + v[2]*v[2]
@@ -150,9 +235,13 @@ func (v Vec3) Norm() float32 {
 }
 
 // Normalized returns a copy of v with elements normalized so the returned
-// vector has length 1.
+// vector has length 1, or the zero vector if the length is 0.
 func (v Vec3) Normalized() Vec3 {
-	f := 1.0 / v.Norm()
+	norm := v.Norm()
+	if norm == 0 {
+		return Vec3{}
+	}
+	f := 1.0 / norm
 	return Vec3{f * v[0], f * v[1], f * v[2]}
 }
 
@@ -170,11 +259,15 @@ func (v Vec3) DropZ() Vec2 {
 	return Vec2{v[0], v[1]}
 }
 
-// ByW returns a 2-element vector where x and y are the same as in v but divided
-// by z. This can be useful when going back from a homogeneous 3-element vector
-// with z != 1, down one dimension to a 2-element vector.
+// ByZ returns a 2-element vector created by dividing x and y by z. This can be
+// useful when going back from a homogeneous 3-element vector with z != 1, down
+// one dimension to a 2-element vector. If z is 0, x and y are returned
+// unchanged.
 func (v Vec3) ByZ() Vec2 {
-	f := 1.0 / v[2]
+	f := float32(1.0)
+	if v[2] != 0 {
+		f = 1.0 / v[2]
+	}
 	return Vec2{f * v[0], f * v[1]}
 }
 
@@ -239,9 +332,13 @@ func (v Vec4) Norm() float32 {
 }
 
 // Normalized returns a copy of v with elements normalized so the returned
-// vector has length 1.
+// vector has length 1, or the zero vector if the length is 0.
 func (v Vec4) Normalized() Vec4 {
-	f := 1.0 / v.Norm()
+	norm := v.Norm()
+	if norm == 0 {
+		return Vec4{}
+	}
+	f := 1.0 / norm
 	return Vec4{f * v[0], f * v[1], f * v[2], f * v[3]}
 }
 
@@ -253,11 +350,15 @@ func (v Vec4) DropW() Vec3 {
 	return Vec3{v[0], v[1], v[2]}
 }
 
-// ByW returns a 3-element vector where x, y and z are the same as in v but
-// divided by w. This can be useful when going back from a homogeneous 4-element
-// vector with w != 1, down one dimension to a 3-element vector.
+// ByW returns a 3-element vector created by dividing x, y and z by w. This can
+// be useful when going back from a homogeneous 4-element vector with w != 1,
+// down one dimension to a 3-element vector. If w is 0, x, y and z are returned
+// unchanged.
 func (v Vec4) ByW() Vec3 {
-	f := 1.0 / v[3]
+	f := float32(1.0)
+	if v[3] != 0 {
+		f = 1.0 / v[3]
+	}
 	return Vec3{f * v[0], f * v[1], f * v[2]}
 }
 
@@ -327,6 +428,25 @@ func (m Mat2) Transposed() Mat2 {
 	}
 }
 
+// Determinant returns the determinant of m.
+func (m Mat2) Determinant() float32 {
+	return m[0]*m[3] - m[1]*m[2]
+}
+
+// Inverse returns the inverse of m. If m is singular, i.e. its determinant is
+// 0, ok is false and the identity matrix is returned.
+func (m Mat2) Inverse() (inverse Mat2, ok bool) {
+	det := m.Determinant()
+	if det == 0 {
+		return Identity2(), false
+	}
+	f := 1 / det
+	return Mat2{
+		f * m[3], -f * m[1],
+		-f * m[2], f * m[0],
+	}, true
+}
+
 // Homogeneous returns the homogeneous 3-dimensional equivalent of the
 // 2-dimensional matrix.
 func (m Mat2) Homogeneous() Mat3 {
@@ -404,6 +524,31 @@ func (m Mat3) Transposed() Mat3 {
 	}
 }
 
+// Determinant returns the determinant of m.
+func (m Mat3) Determinant() float32 {
+	return m[0]*(m[4]*m[8]-m[5]*m[7]) +
+		m[1]*(m[5]*m[6]-m[3]*m[8]) +
+		m[2]*(m[3]*m[7]-m[4]*m[6])
+}
+
+// Inverse returns the inverse of m. If m is singular, i.e. its determinant is
+// 0, ok is false and the identity matrix is returned.
+func (m Mat3) Inverse() (inverse Mat3, ok bool) {
+	c0 := m[4]*m[8] - m[5]*m[7]
+	c1 := m[5]*m[6] - m[3]*m[8]
+	c2 := m[3]*m[7] - m[4]*m[6]
+	det := m[0]*c0 + m[1]*c1 + m[2]*c2
+	if det == 0 {
+		return Identity3(), false
+	}
+	f := 1 / det
+	return Mat3{
+		f * c0, f * (m[2]*m[7] - m[1]*m[8]), f * (m[1]*m[5] - m[2]*m[4]),
+		f * c1, f * (m[0]*m[8] - m[2]*m[6]), f * (m[2]*m[3] - m[0]*m[5]),
+		f * c2, f * (m[1]*m[6] - m[0]*m[7]), f * (m[0]*m[4] - m[1]*m[3]),
+	}, true
+}
+
 // Homogeneous returns the homogeneous 4-dimensional equivalent of the
 // 3-dimensional matrix.
 func (m Mat3) Homogeneous() Mat4 {
@@ -423,6 +568,11 @@ func (m Mat3) String() string {
 
 // Mat2x3 is a 2x3 matrix of float32s in row-major order. It represents a
 // homogeneous 3x3 matrix where the last line is 0,0,1 implicitly.
+//
+// Unlike Mat3 and Mat4, which transform row vectors, Mat2x3 transforms column
+// vectors, i.e. it multiplies the column x,y,1 from the left, see
+// Vec2.TransformCoord2x3. This is why the product m * n first applies n and
+// then m.
 type Mat2x3 [6]float32
 
 // Add returns the sum of m + n.
@@ -441,7 +591,8 @@ func (m Mat2x3) Sub(n Mat2x3) (diff Mat2x3) {
 	return
 }
 
-// Mul returns the product of m * n.
+// Mul returns the product of m * n, which transforms 2D vectors by n first and
+// then by m.
 func (m Mat2x3) Mul(n Mat2x3) Mat2x3 {
 	return Mat2x3{
 		m[0]*n[0] + m[1]*n[3],
@@ -462,7 +613,9 @@ func Identity2x3() Mat2x3 {
 	}
 }
 
-// Mul2x3 returns the product of the given matrices.
+// Mul2x3 returns the product of the given matrices. Since Mat2x3 transforms
+// column vectors, the product applies the matrices from last to first, which
+// is the opposite order of Mul3 and Mul4.
 func Mul2x3(m0 Mat2x3, m ...Mat2x3) Mat2x3 {
 	if len(m) == 0 {
 		return m0
@@ -470,7 +623,32 @@ func Mul2x3(m0 Mat2x3, m ...Mat2x3) Mat2x3 {
 	return m0.Mul(Mul2x3(m[0], m[1:]...))
 }
 
-// ToMat3 returns the 3 by 3 representation of m.
+// Determinant returns the determinant of m, which is the determinant of its
+// homogeneous 3 by 3 representation.
+func (m Mat2x3) Determinant() float32 {
+	return m[0]*m[4] - m[1]*m[3]
+}
+
+// Inverse returns the inverse of m. If m is singular, i.e. its determinant is
+// 0, ok is false and the identity matrix is returned.
+func (m Mat2x3) Inverse() (inverse Mat2x3, ok bool) {
+	det := m.Determinant()
+	if det == 0 {
+		return Identity2x3(), false
+	}
+	f := 1 / det
+	a, b := f*m[4], -f*m[1]
+	c, d := -f*m[3], f*m[0]
+	return Mat2x3{
+		a, b, -(a*m[2] + b*m[5]),
+		c, d, -(c*m[2] + d*m[5]),
+	}, true
+}
+
+// ToMat3 returns the 3 by 3 representation of m. Like m, it transforms column
+// vectors, with the translation in its last column. The Vec2 transforms
+// with a Mat3 use row vectors, for them use m.ToMat3().Transposed(), which has
+// the translation in its last row, like ToMat4.
 func (m Mat2x3) ToMat3() Mat3 {
 	return Mat3{
 		m[0], m[1], m[2],
@@ -479,11 +657,87 @@ func (m Mat2x3) ToMat3() Mat3 {
 	}
 }
 
+// ToMat4 returns the 4 by 4 matrix that transforms the x and y of 3D points
+// like m transforms 2D points and keeps z unchanged. Use it to pass a 2D
+// transform to Direct3D, e.g. as the world matrix of the fixed-function
+// pipeline.
+func (m Mat2x3) ToMat4() Mat4 {
+	return Mat4{
+		m[0], m[3], 0, 0,
+		m[1], m[4], 0, 0,
+		0, 0, 1, 0,
+		m[2], m[5], 0, 1,
+	}
+}
+
 func (m Mat2x3) String() string {
 	return fmt.Sprintf(`%.2f %.2f %.2f
 %.2f %.2f %.2f`, m[0], m[1], m[2], m[3], m[4], m[5])
 }
 
+// Translate2D returns a 2 by 3 matrix that moves 2D points by dx and dy.
+func Translate2D(dx, dy float32) Mat2x3 {
+	return Mat2x3{
+		1, 0, dx,
+		0, 1, dy,
+	}
+}
+
+// Translate2DV is the same as Translate2D, but it takes a Vec2 as its argument
+// instead of single x, y parameters.
+func Translate2DV(v Vec2) Mat2x3 {
+	return Translate2D(v[0], v[1])
+}
+
+// ScaleUniform2D returns a 2 by 3 matrix that scales 2D vectors by the given
+// factor in x and y.
+func ScaleUniform2D(s float32) Mat2x3 {
+	return Scale2D(s, s)
+}
+
+// Scale2D returns a 2 by 3 matrix that scales 2D vectors by the given factors
+// in x and y.
+func Scale2D(dx, dy float32) Mat2x3 {
+	return Mat2x3{
+		dx, 0, 0,
+		0, dy, 0,
+	}
+}
+
+// Scale2DV is the same as Scale2D, but it takes a Vec2 as its argument instead
+// of single x, y parameters.
+func Scale2DV(v Vec2) Mat2x3 {
+	return Scale2D(v[0], v[1])
+}
+
+// RotateLeftHand2D returns a 2 by 3 matrix that rotates 2D vectors about the
+// origin by the given number of turns. 1 turn is 2*Pi. Positive turns rotate
+// the x-axis towards the y-axis, like RotateLeftHandZ does in 3D.
+func RotateLeftHand2D(turns float32) Mat2x3 {
+	s, c := math.Sincos(turnsToRadians(turns))
+	sin, cos := float32(s), float32(c)
+	return Mat2x3{
+		cos, -sin, 0,
+		sin, cos, 0,
+	}
+}
+
+// RotateRightHand2D returns a 2 by 3 matrix that rotates 2D vectors about the
+// origin by the given number of turns. 1 turn is 2*Pi. Positive turns rotate
+// the y-axis towards the x-axis, like RotateRightHandZ does in 3D.
+func RotateRightHand2D(turns float32) Mat2x3 {
+	return RotateLeftHand2D(-turns)
+}
+
+// Shear2D returns a 2 by 3 matrix that shears 2D vectors. The new x is
+// x + dx*y and the new y is y + dy*x.
+func Shear2D(dx, dy float32) Mat2x3 {
+	return Mat2x3{
+		1, dx, 0,
+		dy, 1, 0,
+	}
+}
+
 // Mat4 is a 4 by 4 matrix of float32s in row-major order.
 type Mat4 [16]float32
 
@@ -505,6 +759,9 @@ func (m Mat4) Sub(n Mat4) (diff Mat4) {
 
 // Mul returns the product of m * n.
 func (m Mat4) Mul(n Mat4) Mat4 {
+	if simd.Enabled {
+		return mulSIMD(&m, &n)
+	}
 	return Mat4{
 		m[0]*n[0] + m[1]*n[4] + m[2]*n[8] + m[3]*n[12],
 		m[0]*n[1] + m[1]*n[5] + m[2]*n[9] + m[3]*n[13],
@@ -556,6 +813,121 @@ func Identity4() Mat4 {
 	}
 }
 
+// Determinant returns the determinant of m.
+func (m Mat4) Determinant() float32 {
+	s0 := m[0]*m[5] - m[4]*m[1]
+	s1 := m[0]*m[6] - m[4]*m[2]
+	s2 := m[0]*m[7] - m[4]*m[3]
+	s3 := m[1]*m[6] - m[5]*m[2]
+	s4 := m[1]*m[7] - m[5]*m[3]
+	s5 := m[2]*m[7] - m[6]*m[3]
+
+	c5 := m[10]*m[15] - m[14]*m[11]
+	c4 := m[9]*m[15] - m[13]*m[11]
+	c3 := m[9]*m[14] - m[13]*m[10]
+	c2 := m[8]*m[15] - m[12]*m[11]
+	c1 := m[8]*m[14] - m[12]*m[10]
+	c0 := m[8]*m[13] - m[12]*m[9]
+
+	return s0*c5 - s1*c4 + s2*c3 + s3*c2 - s4*c1 + s5*c0
+}
+
+// Adjugate returns the adjugate of m, which is the transpose of its cofactor
+// matrix. Multiplying m with its adjugate gives the identity matrix scaled by
+// the determinant of m.
+func (m Mat4) Adjugate() Mat4 {
+	s0 := m[0]*m[5] - m[4]*m[1]
+	s1 := m[0]*m[6] - m[4]*m[2]
+	s2 := m[0]*m[7] - m[4]*m[3]
+	s3 := m[1]*m[6] - m[5]*m[2]
+	s4 := m[1]*m[7] - m[5]*m[3]
+	s5 := m[2]*m[7] - m[6]*m[3]
+
+	c5 := m[10]*m[15] - m[14]*m[11]
+	c4 := m[9]*m[15] - m[13]*m[11]
+	c3 := m[9]*m[14] - m[13]*m[10]
+	c2 := m[8]*m[15] - m[12]*m[11]
+	c1 := m[8]*m[14] - m[12]*m[10]
+	c0 := m[8]*m[13] - m[12]*m[9]
+
+	return Mat4{
+		m[5]*c5 - m[6]*c4 + m[7]*c3,
+		-m[1]*c5 + m[2]*c4 - m[3]*c3,
+		m[13]*s5 - m[14]*s4 + m[15]*s3,
+		-m[9]*s5 + m[10]*s4 - m[11]*s3,
+
+		-m[4]*c5 + m[6]*c2 - m[7]*c1,
+		m[0]*c5 - m[2]*c2 + m[3]*c1,
+		-m[12]*s5 + m[14]*s2 - m[15]*s1,
+		m[8]*s5 - m[10]*s2 + m[11]*s1,
+
+		m[4]*c4 - m[5]*c2 + m[7]*c0,
+		-m[0]*c4 + m[1]*c2 - m[3]*c0,
+		m[12]*s4 - m[13]*s2 + m[15]*s0,
+		-m[8]*s4 + m[9]*s2 - m[11]*s0,
+
+		-m[4]*c3 + m[5]*c1 - m[6]*c0,
+		m[0]*c3 - m[1]*c1 + m[2]*c0,
+		-m[12]*s3 + m[13]*s1 - m[14]*s0,
+		m[8]*s3 - m[9]*s1 + m[10]*s0,
+	}
+}
+
+// Inverse returns the inverse of m, like D3DXMatrixInverse. If m is singular,
+// i.e. its determinant is 0, ok is false and the identity matrix is returned.
+func (m Mat4) Inverse() (inverse Mat4, ok bool) {
+	det := m.Determinant()
+	if det == 0 {
+		return Identity4(), false
+	}
+	adj := m.Adjugate()
+	f := 1 / det
+	for i := range inverse {
+		inverse[i] = f * adj[i]
+	}
+	return inverse, true
+}
+
+// NormalMatrix returns the inverse transpose of the upper left 3 by 3 part of
+// m. Multiply normal vectors with it to keep them perpendicular to surfaces
+// that are transformed by m. If that part is singular, ok is false and the
+// identity matrix is returned.
+func (m Mat4) NormalMatrix() (normal Mat3, ok bool) {
+	inv, ok := Mat3{
+		m[0], m[1], m[2],
+		m[4], m[5], m[6],
+		m[8], m[9], m[10],
+	}.Inverse()
+	return inv.Transposed(), ok
+}
+
+// InverseAffine returns the inverse of m like Inverse, but faster. It only
+// works if m is an affine transformation, e.g. a combination of translations,
+// scales and rotations, where the last column is 0, 0, 0, 1. If m is singular,
+// ok is false and the identity matrix is returned.
+func (m Mat4) InverseAffine() (inverse Mat4, ok bool) {
+	c0 := m[5]*m[10] - m[6]*m[9]
+	c1 := m[6]*m[8] - m[4]*m[10]
+	c2 := m[4]*m[9] - m[5]*m[8]
+	det := m[0]*c0 + m[1]*c1 + m[2]*c2
+	if det == 0 {
+		return Identity4(), false
+	}
+	f := 1 / det
+	i0, i1, i2 := f*c0, f*(m[2]*m[9]-m[1]*m[10]), f*(m[1]*m[6]-m[2]*m[5])
+	i4, i5, i6 := f*c1, f*(m[0]*m[10]-m[2]*m[8]), f*(m[2]*m[4]-m[0]*m[6])
+	i8, i9, i10 := f*c2, f*(m[1]*m[8]-m[0]*m[9]), f*(m[0]*m[5]-m[1]*m[4])
+	return Mat4{
+		i0, i1, i2, 0,
+		i4, i5, i6, 0,
+		i8, i9, i10, 0,
+		-(m[12]*i0 + m[13]*i4 + m[14]*i8),
+		-(m[12]*i1 + m[13]*i5 + m[14]*i9),
+		-(m[12]*i2 + m[13]*i6 + m[14]*i10),
+		1,
+	}, true
+}
+
 // Translate returns 4 by 4 matrix that, when multiplied with a homogeneous
 // 4-element 3D vector, moves the vector by the given amounts in x, y and z.
 func Translate(dx, dy, dz float32) Mat4 {
@@ -746,6 +1118,10 @@ func (m Mat4) String() string {
 // translation matrices that, when multiplied in that order, produce the
 // original matrix. See this forum post for reference:
 // https://math.stackexchange.com/questions/237369/given-this-transformation-matrix-how-do-i-decompose-it-into-translation-rotati
+//
+// The scale is never negative, so if m mirrors space, rotation is a reflection
+// instead of a rotation. An axis that m scales to 0 gets a row of zeros in
+// rotation. Decompose handles both cases and always returns a proper rotation.
 func DecomposeAffineTransform(m Mat4) (scale, rotation, translation Mat4) {
 	translation = Mat4{
 		1, 0, 0, 0,
@@ -762,7 +1138,7 @@ func DecomposeAffineTransform(m Mat4) (scale, rotation, translation Mat4) {
 		0, 0, sz, 0,
 		0, 0, 0, 1,
 	}
-	fx, fy, fz := 1/sx, 1/sy, 1/sz
+	fx, fy, fz := inverseOrZero(sx), inverseOrZero(sy), inverseOrZero(sz)
 	rotation = Mat4{
 		fx * m[0], fx * m[1], fx * m[2], 0,
 		fy * m[4], fy * m[5], fy * m[6], 0,
@@ -771,3 +1147,114 @@ func DecomposeAffineTransform(m Mat4) (scale, rotation, translation Mat4) {
 	}
 	return
 }
+
+// inverseOrZero returns 1/x, or 0 if x is 0.
+func inverseOrZero(x float32) float32 {
+	if x == 0 {
+		return 0
+	}
+	return 1 / x
+}
+
+// Decompose splits the affine transformation m into scale, rotation and
+// translation, like D3DXMatrixDecompose, so that
+//
+//	Transformation(Vec3{}, IdentityQuat(), scale, Vec3{}, rotation, translation)
+//
+// gives back m. If m mirrors space, i.e. its determinant is negative, the x
+// scale is negative and rotation stays a proper rotation. Axes that are scaled
+// to 0 get a scale of 0 and an axis perpendicular to the others, so m need not
+// be invertible.
+//
+// ok is false if m has a shear or perspective part, which cannot be
+// represented this way. The returned values are then the closest scale,
+// rotation and translation that Decompose could find.
+func Decompose(m Mat4) (scale Vec3, rotation Quat, translation Vec3, ok bool) {
+	translation = Vec3{m[12], m[13], m[14]}
+	axes := [3]Vec3{
+		{m[0], m[1], m[2]},
+		{m[4], m[5], m[6]},
+		{m[8], m[9], m[10]},
+	}
+	scale, axes, ok = orthonormalizeAxes(axes)
+	rotation = rotationToQuat(
+		axes[0][0], axes[0][1], axes[0][2],
+		axes[1][0], axes[1][1], axes[1][2],
+		axes[2][0], axes[2][1], axes[2][2],
+	)
+	if !m.IsAffine(1e-5) {
+		ok = false
+	}
+	return
+}
+
+// orthonormalizeAxes normalizes the scaled axes of a rotation matrix and
+// returns their lengths as scale. Axes of length 0 are replaced so the
+// result is a right-handed frame. If the given axes are left-handed, the
+// first axis and scale are negated. ok is false if the axes are not
+// perpendicular.
+func orthonormalizeAxes(axes [3]Vec3) (scale Vec3, frame [3]Vec3, ok bool) {
+	const eps = 1e-5
+	var maxScale float32
+	for i, a := range axes {
+		scale[i] = a.Norm()
+		if scale[i] > maxScale {
+			maxScale = scale[i]
+		}
+	}
+	var zero [3]bool
+	zeros := 0
+	for i, a := range axes {
+		if scale[i] <= eps*maxScale {
+			scale[i] = 0
+			zero[i] = true
+			zeros++
+		} else {
+			frame[i] = a.MulScalar(1 / scale[i])
+		}
+	}
+
+	switch zeros {
+	case 1:
+		for i := range frame {
+			if zero[i] {
+				frame[i] = frame[(i+1)%3].Cross(frame[(i+2)%3]).Normalized()
+			}
+		}
+	case 2:
+		for i := range frame {
+			if !zero[i] {
+				a := frame[i]
+				helper := Vec3{1, 0, 0}
+				if abs(a[0]) > abs(a[1]) || abs(a[0]) > abs(a[2]) {
+					helper = Vec3{0, 1, 0}
+					if abs(a[1]) > abs(a[2]) {
+						helper = Vec3{0, 0, 1}
+					}
+				}
+				b := a.Cross(helper).Normalized()
+				frame[(i+1)%3] = b
+				frame[(i+2)%3] = a.Cross(b)
+			}
+		}
+	case 3:
+		frame = [3]Vec3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	}
+
+	ok = abs(frame[0].Dot(frame[1])) <= eps &&
+		abs(frame[0].Dot(frame[2])) <= eps &&
+		abs(frame[1].Dot(frame[2])) <= eps
+
+	if frame[0].Dot(frame[1].Cross(frame[2])) < 0 {
+		scale[0] = -scale[0]
+		frame[0] = frame[0].Negate()
+	}
+	return
+}
+
+func abs(x float32) float32 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
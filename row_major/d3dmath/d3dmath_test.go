@@ -47,6 +47,31 @@ func TestVec2MulMat(t *testing.T) {
 	checkFloats(t, v[:], 19, 17)
 }
 
+func TestVec2Transform(t *testing.T) {
+	m := Mat3{
+		2, 4, 0,
+		5, 3, 0,
+		7, 8, 2,
+	}
+	v := Vec2{2, 3}.Transform(m)
+	checkFloats(t, v[:], 4+15+7, 8+9+8, 2)
+	c := Vec2{2, 3}.TransformCoord(m)
+	checkFloats(t, c[:], 13, 12.5)
+	n := Vec2{2, 3}.TransformNormal(m)
+	checkFloats(t, n[:], 4+15, 8+9)
+}
+
+func TestVec2Transform2x3(t *testing.T) {
+	m := Mat2x3{
+		1, 2, 3,
+		4, 5, 6,
+	}
+	c := Vec2{2, 3}.TransformCoord2x3(m)
+	checkFloats(t, c[:], 2+6+3, 8+15+6)
+	n := Vec2{2, 3}.TransformNormal2x3(m)
+	checkFloats(t, n[:], 2+6, 8+15)
+}
+
 func TestVec2SquareNorm(t *testing.T) {
 	v := Vec2{2, 3}.SquareNorm()
 	checkFloat(t, v, 13)
@@ -60,6 +85,8 @@ func TestVec2Norm(t *testing.T) {
 func TestVec2Normalized(t *testing.T) {
 	v := Vec2{3, 4}.Normalized()
 	checkFloats(t, v[:], 3.0/5, 4.0/5)
+	v = Vec2{}.Normalized()
+	checkFloats(t, v[:], 0, 0)
 }
 
 func TestVec2Homogeneous(t *testing.T) {
@@ -119,6 +146,21 @@ func TestVec3MulMat3(t *testing.T) {
 	checkFloats(t, v[:], 4+15+28, 8+9+32, 6+3+8)
 }
 
+func TestVec3Transform(t *testing.T) {
+	m := Mat4{
+		2, 4, 3, 0,
+		5, 3, 1, 0,
+		7, 8, 2, 0,
+		3, 2, 5, 2,
+	}
+	v := Vec3{2, 3, 4}.Transform(m)
+	checkFloats(t, v[:], 4+15+28+3, 8+9+32+2, 6+3+8+5, 2)
+	c := Vec3{2, 3, 4}.TransformCoord(m)
+	checkFloats(t, c[:], 25, 25.5, 11)
+	n := Vec3{2, 3, 4}.TransformNormal(m)
+	checkFloats(t, n[:], 4+15+28, 8+9+32, 6+3+8)
+}
+
 func TestVec3SquareNorm(t *testing.T) {
 	v := Vec3{2, 3, 4}.SquareNorm()
 	checkFloat(t, v, 29)
@@ -133,6 +175,8 @@ func TestVec3Normalized(t *testing.T) {
 	v := Vec3{2, 3, 4}.Normalized()
 	f := 1.0 / float32(math.Sqrt(29))
 	checkFloats(t, v[:], 2*f, 3*f, 4*f)
+	v = Vec3{}.Normalized()
+	checkFloats(t, v[:], 0, 0, 0)
 }
 
 func TestVec3Homogeneous(t *testing.T) {
@@ -148,6 +192,9 @@ func TestVec3DropZ(t *testing.T) {
 func TestVec3ByZ(t *testing.T) {
 	v := Vec3{1, 2, 3}.ByZ()
 	checkFloats(t, v[:], 1.0/3, 2.0/3)
+	// A z of 0 leaves x and y unchanged.
+	v = Vec3{1, 2, 0}.ByZ()
+	checkFloats(t, v[:], 1, 2)
 }
 
 func TestVec3String(t *testing.T) {
@@ -212,6 +259,8 @@ func TestVec4Normalized(t *testing.T) {
 	v := Vec4{2, 3, 4, 5}.Normalized()
 	f := 1.0 / float32(math.Sqrt(54))
 	checkFloats(t, v[:], 2*f, 3*f, 4*f, 5*f)
+	v = Vec4{}.Normalized()
+	checkFloats(t, v[:], 0, 0, 0, 0)
 }
 
 func TestVec4DropW(t *testing.T) {
@@ -222,6 +271,9 @@ func TestVec4DropW(t *testing.T) {
 func TestVec4ByW(t *testing.T) {
 	v := Vec4{2, 3, 4, 5}.ByW()
 	checkFloats(t, v[:], 2.0/5, 3.0/5, 4.0/5)
+	// A w of 0 leaves x, y and z unchanged.
+	v = Vec4{2, 3, 4, 0}.ByW()
+	checkFloats(t, v[:], 2, 3, 4)
 }
 
 func TestVec4String(t *testing.T) {
@@ -318,6 +370,38 @@ func TestMat2Transposed(t *testing.T) {
 	)
 }
 
+func TestMat2Determinant(t *testing.T) {
+	m := Mat2{
+		1, 2,
+		3, 4,
+	}
+	checkFloat(t, m.Determinant(), -2)
+}
+
+func TestMat2Inverse(t *testing.T) {
+	m, ok := Mat2{
+		1, 2,
+		3, 4,
+	}.Inverse()
+	if !ok {
+		t.Error("matrix should be invertible")
+	}
+	checkFloats(t, m[:],
+		-2, 1,
+		1.5, -0.5,
+	)
+
+	m, ok = Mat2{
+		1, 2,
+		2, 4,
+	}.Inverse()
+	if ok {
+		t.Error("singular matrix was inverted")
+	}
+	id := Identity2()
+	checkFloats(t, m[:], id[:]...)
+}
+
 func TestMat2Homogeneous(t *testing.T) {
 	m := Mat2{
 		1, 2,
@@ -435,6 +519,45 @@ func TestMat3Transposed(t *testing.T) {
 	)
 }
 
+func TestMat3Determinant(t *testing.T) {
+	m := Mat3{
+		2, 0, 1,
+		1, 3, 2,
+		1, 1, 2,
+	}
+	checkFloat(t, m.Determinant(), 6)
+}
+
+func TestMat3Inverse(t *testing.T) {
+	m := Mat3{
+		2, 0, 1,
+		1, 3, 2,
+		1, 1, 2,
+	}
+	inv, ok := m.Inverse()
+	if !ok {
+		t.Error("matrix should be invertible")
+	}
+	checkFloatsNear(t, inv[:],
+		4.0/6, 1.0/6, -3.0/6,
+		0, 3.0/6, -3.0/6,
+		-2.0/6, -2.0/6, 1,
+	)
+	prod := m.Mul(inv)
+	id := Identity3()
+	checkFloatsNear(t, prod[:], id[:]...)
+
+	inv, ok = Mat3{
+		1, 2, 3,
+		4, 5, 6,
+		7, 8, 9,
+	}.Inverse()
+	if ok {
+		t.Error("singular matrix was inverted")
+	}
+	checkFloats(t, inv[:], id[:]...)
+}
+
 func TestMat3Homogeneous(t *testing.T) {
 	m := Mat3{
 		1, 2, 5,
@@ -528,6 +651,51 @@ func TestMulMat2x3(t *testing.T) {
 	)
 }
 
+func TestMat2x3Determinant(t *testing.T) {
+	m := Mat2x3{
+		1, 2, 3,
+		4, 5, 6,
+	}
+	checkFloat(t, m.Determinant(), -3)
+}
+
+func TestMat2x3Inverse(t *testing.T) {
+	m, ok := Mat2x3{
+		2, 0, 3,
+		0, 4, 5,
+	}.Inverse()
+	if !ok {
+		t.Error("matrix should be invertible")
+	}
+	checkFloats(t, m[:],
+		0.5, 0, -1.5,
+		0, 0.25, -1.25,
+	)
+
+	m = Mat2x3{
+		1, 2, 3,
+		4, 5, 6,
+	}
+	inv, ok := m.Inverse()
+	if !ok {
+		t.Error("matrix should be invertible")
+	}
+	id := Identity2x3()
+	prod := m.Mul(inv)
+	checkFloatsNear(t, prod[:], id[:]...)
+	prod = inv.Mul(m)
+	checkFloatsNear(t, prod[:], id[:]...)
+
+	inv, ok = Mat2x3{
+		1, 2, 3,
+		2, 4, 6,
+	}.Inverse()
+	if ok {
+		t.Error("singular matrix was inverted")
+	}
+	checkFloats(t, inv[:], id[:]...)
+}
+
 func TestMat2x3ToMat3(t *testing.T) {
 	m := Mat2x3{
 		1, 2, 3,
@@ -540,6 +708,102 @@ func TestMat2x3ToMat3(t *testing.T) {
 	)
 }
 
+func TestMat2x3ConversionsTransformLikeMat2x3(t *testing.T) {
+	a, b := RotateLeftHand2D(0.1), Translate2D(2, 3)
+	m := Mul2x3(a, b, Scale2D(2, 4))
+	m3 := m.ToMat3().Transposed()
+	m4 := m.ToMat4()
+	for _, p := range []Vec2{{0, 0}, {1, 0}, {0, 1}, {-2, 5}} {
+		want := p.TransformCoord2x3(m)
+		// Mul2x3 applies its matrices from last to first.
+		steps := p.TransformCoord2x3(Scale2D(2, 4)).
+			TransformCoord2x3(b).TransformCoord2x3(a)
+		checkFloatsNear(t, steps[:], want[:]...)
+		have3 := p.TransformCoord(m3)
+		checkFloatsNear(t, have3[:], want[:]...)
+		have4 := Vec3{p[0], p[1], 0}.TransformCoord(m4)
+		checkFloatsNear(t, have4[:], want[0], want[1], 0)
+		n := p.TransformNormal2x3(m)
+		haveN := p.TransformNormal(m3)
+		checkFloatsNear(t, haveN[:], n[:]...)
+	}
+	// ToMat3 itself transforms column vectors, like m.
+	m3 = m.ToMat3()
+	p := Vec2{-2, 5}
+	want := p.TransformCoord2x3(m)
+	checkFloatsNear(t, []float32{
+		m3[0]*p[0] + m3[1]*p[1] + m3[2],
+		m3[3]*p[0] + m3[4]*p[1] + m3[5],
+	}, want[:]...)
+}
+
+func TestMat2x3ToMat4(t *testing.T) {
+	m := Mat2x3{
+		1, 2, 3,
+		4, 5, 6,
+	}.ToMat4()
+	checkFloats(t, m[:],
+		1, 4, 0, 0,
+		2, 5, 0, 0,
+		0, 0, 1, 0,
+		3, 6, 0, 1,
+	)
+
+	m2 := Mul2x3(
+		Translate2D(2, 3),
+		RotateLeftHand2D(0.1),
+		Scale2D(2, 4),
+		Shear2D(0.5, 0),
+	)
+	m4 := m2.ToMat4()
+	for _, p := range []Vec2{{0, 0}, {1, 0}, {0, 1}, {-2, 5}} {
+		want := p.TransformCoord2x3(m2)
+		have := Vec3{p[0], p[1], 5}.TransformCoord(m4)
+		checkFloatsNear(t, have[:], want[0], want[1], 5)
+	}
+}
+
+func TestTranslate2D(t *testing.T) {
+	m := Translate2D(2, 3)
+	checkFloats(t, m[:],
+		1, 0, 2,
+		0, 1, 3,
+	)
+	p := Vec2{1, 1}.TransformCoord2x3(m)
+	checkFloats(t, p[:], 3, 4)
+	d := Vec2{1, 1}.TransformNormal2x3(m)
+	checkFloats(t, d[:], 1, 1)
+}
+
+func TestScale2D(t *testing.T) {
+	m := Scale2D(2, 3)
+	checkFloats(t, m[:],
+		2, 0, 0,
+		0, 3, 0,
+	)
+	m = ScaleUniform2D(2)
+	checkFloats(t, m[:],
+		2, 0, 0,
+		0, 2, 0,
+	)
+}
+
+func TestRotate2D(t *testing.T) {
+	v := Vec2{1, 0}.TransformCoord2x3(RotateLeftHand2D(0.25))
+	checkFloatsNear(t, v[:], 0, 1)
+	v = Vec2{1, 0}.TransformCoord2x3(RotateRightHand2D(0.25))
+	checkFloatsNear(t, v[:], 0, -1)
+
+	m := RotateLeftHand2D(0.1).ToMat4()
+	want := RotateLeftHandZ(0.1)
+	checkFloatsNear(t, m[:], want[:]...)
+}
+
+func TestShear2D(t *testing.T) {
+	v := Vec2{2, 3}.TransformCoord2x3(Shear2D(0.5, 2))
+	checkFloats(t, v[:], 2+0.5*3, 3+2*2)
+}
+
 func TestMat2x3String(t *testing.T) {
 	m := Mat2x3{
 		1, 2, 3,
@@ -721,28 +985,245 @@ func TestDecomposeAffine(t *testing.T) {
 	checkFloats(t, m2[:], m[:]...)
 }
 
+func TestDecomposeAffineParts(t *testing.T) {
+	// Each part must come out as it went in, not only their product.
+	rotation := RotateRightHandAbout(Vec3{1, 2, 3}, 0.2)
+	m := Mul4(Scale(2, 3, 4), rotation, Translate(5, -6, 7))
+	scale, rot, trans := DecomposeAffineTransform(m)
+	want := Scale(2, 3, 4)
+	checkFloatsNear(t, scale[:], want[:]...)
+	checkFloatsNear(t, rot[:], rotation[:]...)
+	want = Translate(5, -6, 7)
+	checkFloatsNear(t, trans[:], want[:]...)
+}
+
+func TestDecomposeAffineLimits(t *testing.T) {
+	// An axis scaled to 0 leaves a row of zeros in the rotation.
+	m := Mul4(Scale(2, 0, 4), Translate(5, -6, 7))
+	scale, rot, trans := DecomposeAffineTransform(m)
+	checkFloats(t, scale[:],
+		2, 0, 0, 0,
+		0, 0, 0, 0,
+		0, 0, 4, 0,
+		0, 0, 0, 1,
+	)
+	checkFloats(t, rot[:],
+		1, 0, 0, 0,
+		0, 0, 0, 0,
+		0, 0, 1, 0,
+		0, 0, 0, 1,
+	)
+	m2 := Mul4(scale, rot, trans)
+	checkFloats(t, m2[:], m[:]...)
+
+	// A mirroring is returned as part of the rotation.
+	scale, rot, _ = DecomposeAffineTransform(Scale(-2, 3, 4))
+	checkFloats(t, scale[:],
+		2, 0, 0, 0,
+		0, 3, 0, 0,
+		0, 0, 4, 0,
+		0, 0, 0, 1,
+	)
+	checkFloat(t, rot.Determinant(), -1)
+}
+
+func TestDecompose(t *testing.T) {
+	compose := func(scale Vec3, rotation Quat, translation Vec3) Mat4 {
+		return Transformation(
+			Vec3{}, IdentityQuat(), scale, Vec3{}, rotation, translation,
+		)
+	}
+	check := func(m Mat4, wantScale Vec3) {
+		t.Helper()
+		scale, rotation, translation, ok := Decompose(m)
+		if !ok {
+			t.Error("decomposition failed")
+		}
+		checkFloatsNear(t, scale[:], wantScale[:]...)
+		checkFloatsNear(t, []float32{rotation.Norm()}, 1)
+		m2 := compose(scale, rotation, translation)
+		checkFloatsNear(t, m2[:], m[:]...)
+	}
+
+	q := QuatRightHandAbout(Vec3{3, -4, 5}, 0.3)
+	m := compose(Vec3{2, 3, 4}, q, Vec3{1, -2, 3})
+	scale, rotation, translation, ok := Decompose(m)
+	if !ok {
+		t.Error("decomposition failed")
+	}
+	checkFloatsNear(t, scale[:], 2, 3, 4)
+	if rotation.Dot(q) < 0 {
+		rotation = rotation.Negate()
+	}
+	checkFloatsNear(t, rotation[:], q[:]...)
+	checkFloats(t, translation[:], 1, -2, 3)
+
+	rot := q.ToMat4()
+	trans := Translate(1, -2, 3)
+	// A mirroring matrix has its x scale flipped.
+	check(Mul4(Scale(2, -3, 4), rot, trans), Vec3{-2, 3, 4})
+	check(Mul4(Scale(-1, -1, -1), rot, trans), Vec3{-1, 1, 1})
+	// Two flipped axes are a rotation.
+	check(Mul4(Scale(-2, -3, 4), rot, trans), Vec3{2, 3, 4})
+	// Degenerate axes have a scale of 0.
+	check(Mul4(Scale(2, 0, 4), rot, trans), Vec3{2, 0, 4})
+	check(Mul4(Scale(0, 0, 4), rot, trans), Vec3{0, 0, 4})
+	check(Mul4(Scale(0, 0, 0), rot, trans), Vec3{0, 0, 0})
+
+	_, _, _, ok = Decompose(Mul4(RotateLeftHandZ(0.1), Scale(1, 2, 1)))
+	if ok {
+		t.Error("shear was decomposed")
+	}
+	_, _, _, ok = Decompose(PerspectiveFovLH(1, 1, 1, 10))
+	if ok {
+		t.Error("perspective was decomposed")
+	}
+}
+
+func TestMat4Determinant(t *testing.T) {
+	m := Mat4{
+		3, 2, 0, 1,
+		4, 0, 1, 2,
+		3, 0, 2, 1,
+		9, 2, 3, 1,
+	}
+	checkFloat(t, m.Determinant(), 24)
+	checkFloat(t, Identity4().Determinant(), 1)
+	checkFloat(t, Scale(2, 3, 4).Determinant(), 24)
+}
+
+func TestMat4Adjugate(t *testing.T) {
+	m := Mat4{
+		3, 2, 0, 1,
+		4, 0, 1, 2,
+		3, 0, 2, 1,
+		9, 2, 3, 1,
+	}.Adjugate()
+	checkFloats(t, m[:],
+		-6, 6, -12, 6,
+		16, -12, 12, -4,
+		4, -12, 24, -4,
+		10, 6, 12, -10,
+	)
+}
+
+func TestMat4Inverse(t *testing.T) {
+	id := Identity4()
+	for _, m := range []Mat4{
+		{
+			3, 2, 0, 1,
+			4, 0, 1, 2,
+			3, 0, 2, 1,
+			9, 2, 3, 1,
+		},
+		LookAt(Vec3{1, 2, 3}, Vec3{-4, 5, 0}, Vec3{0, 1, 0}),
+		Perspective(1, 1.5, 0.1, 100),
+	} {
+		inv, ok := m.Inverse()
+		if !ok {
+			t.Errorf("matrix should be invertible:\n%v", m)
+		}
+		prod := m.Mul(inv)
+		checkFloatsNear(t, prod[:], id[:]...)
+		prod = inv.Mul(m)
+		checkFloatsNear(t, prod[:], id[:]...)
+	}
+}
+
+func TestMat4InverseOfSingularMatrix(t *testing.T) {
+	m := Mat4{
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+		9, 1, 2, 3,
+		4, 5, 6, 7,
+	}
+	checkFloat(t, m.Determinant(), 0)
+	inv, ok := m.Inverse()
+	if ok {
+		t.Error("singular matrix was inverted")
+	}
+	id := Identity4()
+	checkFloats(t, inv[:], id[:]...)
+}
+
+func TestMat4NormalMatrix(t *testing.T) {
+	m := Mul4(
+		Scale(2, 5, 0.5),
+		RotateRightHandAbout(Vec3{1, 2, 3}, 0.1),
+		Translate(4, 5, 6),
+	)
+	// A surface with this normal and tangent stays perpendicular to the
+	// normal after the transformation.
+	normal := Vec3{1, 1, 0}
+	tangent := Vec3{1, -1, 2}
+	nm, ok := m.NormalMatrix()
+	if !ok {
+		t.Error("matrix should have a normal matrix")
+	}
+	n := normal.MulMat(nm)
+	tan := Vec4{tangent[0], tangent[1], tangent[2], 0}.MulMat(m).DropW()
+	checkFloatsNear(t, []float32{n.Dot(tan)}, 0)
+
+	// Rotations do not change the normal matrix.
+	rot := RotateLeftHandAbout(Vec3{3, 2, 1}, 0.3)
+	nm, _ = rot.NormalMatrix()
+	nm4 := nm.Homogeneous()
+	checkFloatsNear(t, nm4[:], rot[:]...)
+
+	nm, ok = Scale(1, 0, 1).NormalMatrix()
+	if ok {
+		t.Error("singular matrix has no normal matrix")
+	}
+	id := Identity3()
+	checkFloats(t, nm[:], id[:]...)
+}
+
+func TestMat4InverseAffine(t *testing.T) {
+	m := Mul4(
+		Scale(2, -3, 4),
+		RotateRightHandAbout(Vec3{3, -4, 5}, 0.3),
+		Translate(1, -2, 3),
+	)
+	inv, ok := m.InverseAffine()
+	if !ok {
+		t.Error("affine matrix should be invertible")
+	}
+	want, _ := m.Inverse()
+	checkFloatsNear(t, inv[:], want[:]...)
+	prod := m.Mul(inv)
+	id := Identity4()
+	checkFloatsNear(t, prod[:], id[:]...)
+
+	inv, ok = Scale(1, 0, 1).InverseAffine()
+	if ok {
+		t.Error("singular matrix was inverted")
+	}
+	checkFloats(t, inv[:], id[:]...)
+}
+
 func checkString(t *testing.T, have, want string) {
 	if have != want {
 		t.Errorf("string differs, have %q but want %q", have, want)
 	}
 }
 
+// checkFloat and checkFloats allow a few ULPs of difference because the
+// compiler may fuse multiplications and additions on some platforms, which
+// changes the last bits of a result.
 func checkFloat(t *testing.T, have, want float32) {
-	if have != want {
+	if ULPDistance(have, want) > 4 {
 		t.Errorf("float differs, have %f but want %f", have, want)
 	}
 }
 
 func checkFloats(t *testing.T, have []float32, want ...float32) {
-	eq := len(have) == len(want)
-	if eq {
-		for i := range have {
-			if have[i] != want[i] {
-				eq = false
-			}
-		}
+	if len(have) != len(want) || !equalFloatsULP(have, want, 4) {
+		t.Errorf("floats differ, have\n%v\nbut want\n%v", have, want)
 	}
-	if !eq {
+}
+
+func checkFloatsNear(t *testing.T, have []float32, want ...float32) {
+	if len(have) != len(want) || !approxEqualFloats(have, want, nearTolerance()) {
 		t.Errorf("floats differ, have\n%v\nbut want\n%v", have, want)
 	}
 }
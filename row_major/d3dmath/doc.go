@@ -0,0 +1,7 @@
+/*
+Package d3dmath provides vector and matrix functions for Direct3D. Vectors are
+row vectors and matrices are stored in row-major order.
+*/
+package d3dmath
+
+//go:generate go run ../../internal/gen
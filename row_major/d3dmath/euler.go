@@ -0,0 +1,175 @@
+package d3dmath
+
+import (
+	"fmt"
+	"math"
+)
+
+// EulerOrder is the order of the axes in a sequence of three rotations about
+// the fixed x-, y- and z-axes. EulerXYZ, for example, first rotates about the
+// x-axis, then about the y-axis and then about the z-axis.
+//
+// The first six orders use three different axes and are also called
+// Tait-Bryan angles. The last six are proper Euler angles, they rotate about
+// the first axis again at the end.
+type EulerOrder int
+
+const (
+	EulerXYZ EulerOrder = iota
+	EulerXZY
+	EulerYXZ
+	EulerYZX
+	EulerZXY
+	EulerZYX
+	EulerXYX
+	EulerXZX
+	EulerYXY
+	EulerYZY
+	EulerZXZ
+	EulerZYZ
+)
+
+// eulerAxes holds the axes of each EulerOrder, 0 is the x-, 1 the y- and 2 the
+// z-axis.
+var eulerAxes = [...][3]int{
+	EulerXYZ: {0, 1, 2},
+	EulerXZY: {0, 2, 1},
+	EulerYXZ: {1, 0, 2},
+	EulerYZX: {1, 2, 0},
+	EulerZXY: {2, 0, 1},
+	EulerZYX: {2, 1, 0},
+	EulerXYX: {0, 1, 0},
+	EulerXZX: {0, 2, 0},
+	EulerYXY: {1, 0, 1},
+	EulerYZY: {1, 2, 1},
+	EulerZXZ: {2, 0, 2},
+	EulerZYZ: {2, 1, 2},
+}
+
+func (o EulerOrder) String() string {
+	if o < 0 || int(o) >= len(eulerAxes) {
+		return fmt.Sprintf("EulerOrder(%d)", int(o))
+	}
+	a := eulerAxes[o]
+	return "Euler" + string([]byte{"XYZ"[a[0]], "XYZ"[a[1]], "XYZ"[a[2]]})
+}
+
+// RotateLeftHandEuler returns a 4 by 4 matrix that, when multiplied with a
+// homogeneous 4-element 3D vector, first rotates the vector by a about the
+// first axis of order, then by b about the second and then by c about the
+// third axis, all applying the left-handed rule and given in turns.
+func RotateLeftHandEuler(order EulerOrder, a, b, c float32) Mat4 {
+	return RotateRightHandEuler(order, -a, -b, -c)
+}
+
+// RotateRightHandEuler returns a 4 by 4 matrix that, when multiplied with a
+// homogeneous 4-element 3D vector, first rotates the vector by a about the
+// first axis of order, then by b about the second and then by c about the
+// third axis, all applying the right-handed rule and given in turns.
+func RotateRightHandEuler(order EulerOrder, a, b, c float32) Mat4 {
+	axes := eulerAxes[order]
+	return Mul4(
+		rotateRightHandAxis(axes[0], a),
+		rotateRightHandAxis(axes[1], b),
+		rotateRightHandAxis(axes[2], c),
+	)
+}
+
+func rotateRightHandAxis(axis int, turns float32) Mat4 {
+	switch axis {
+	case 0:
+		return RotateRightHandX(turns)
+	case 1:
+		return RotateRightHandY(turns)
+	}
+	return RotateRightHandZ(turns)
+}
+
+// RotateLeftHandYawPitchRoll returns a 4 by 4 matrix that, when multiplied
+// with a homogeneous 4-element 3D vector, first rotates the vector by roll
+// about the z-axis, then by pitch about the x-axis and then by yaw about the
+// y-axis, all applying the left-handed rule and given in turns. This is the
+// same as D3DXMatrixRotationYawPitchRoll with angles in turns.
+func RotateLeftHandYawPitchRoll(yaw, pitch, roll float32) Mat4 {
+	return RotateLeftHandEuler(EulerZXY, roll, pitch, yaw)
+}
+
+// RotateRightHandYawPitchRoll returns a 4 by 4 matrix that, when multiplied
+// with a homogeneous 4-element 3D vector, first rotates the vector by roll
+// about the z-axis, then by pitch about the x-axis and then by yaw about the
+// y-axis, all applying the right-handed rule and given in turns.
+func RotateRightHandYawPitchRoll(yaw, pitch, roll float32) Mat4 {
+	return RotateRightHandEuler(EulerZXY, roll, pitch, yaw)
+}
+
+// LeftHandEuler returns the angles a, b and c, in turns, for which
+// RotateLeftHandEuler(order, a, b, c) is the rotation in m. The angles lie in
+// the same ranges as those of RightHandEuler.
+func (m Mat4) LeftHandEuler(order EulerOrder) (a, b, c float32) {
+	a, b, c = m.euler(order, -1)
+	return -a, -b, -c
+}
+
+// RightHandEuler returns the angles a, b and c, in turns, for which
+// RotateRightHandEuler(order, a, b, c) is the rotation in m. The upper left 3
+// by 3 part of m must be a rotation without scaling, the translation of m is
+// ignored.
+//
+// The angles a and c lie in the range [-1/2, 1/2]. The angle b lies in
+// [-1/4, 1/4] for the orders with three different axes and in [0, 1/2] for the
+// others. At the ends of the range of b, the first and the last rotation are
+// about the same axis and only their sum or difference is defined. In this
+// case, which is called gimbal lock, a is 0 and c holds the whole rotation.
+func (m Mat4) RightHandEuler(order EulerOrder) (a, b, c float32) {
+	return m.euler(order, 1)
+}
+
+// euler returns the right-handed angles of order in m. For the orders that
+// use the first axis again, the sine of b has the given sign, so that the
+// left-handed angles, which are negated, lie in the same range.
+func (m *Mat4) euler(order EulerOrder, sign float64) (a, b, c float32) {
+	axes := eulerAxes[order]
+	i, j := axes[0], axes[1]
+	k := 3 - i - j
+	// e is 1 if i, j, k are in the cyclic order x, y, z and -1 otherwise.
+	e := 1.0
+	if (j-i+3)%3 != 1 {
+		e = -1
+	}
+	r := [3][3]float64{
+		{float64(m[0]), float64(m[1]), float64(m[2])},
+		{float64(m[4]), float64(m[5]), float64(m[6])},
+		{float64(m[8]), float64(m[9]), float64(m[10])},
+	}
+	// The rows and columns of r are scaled by the sine or cosine of b. If
+	// this is 0, they do not contain a and c anymore.
+	locked := 16 * float64(epsilon())
+	var radA, radB float64
+	if axes[2] == i {
+		sin := math.Hypot(r[i][j], r[i][k])
+		radB = math.Atan2(sign*sin, r[i][i])
+		if sin > locked {
+			radA = math.Atan2(sign*r[j][i], -sign*e*r[k][i])
+		}
+	} else {
+		cos := math.Hypot(r[i][i], r[i][j])
+		radB = math.Atan2(e*r[i][k], cos)
+		if cos > locked {
+			radA = math.Atan2(-e*r[j][k], r[k][k])
+		}
+	}
+	// Undoing the rotation by a leaves the rotations by b and c, whose row j
+	// only depends on c. Computing c this way keeps the three angles
+	// consistent, even if a is imprecise near gimbal lock.
+	sinA, cosA := math.Sincos(radA)
+	row := func(x int) float64 {
+		return cosA*r[j][x] + e*sinA*r[k][x]
+	}
+	var radC float64
+	if axes[2] == i {
+		radC = math.Atan2(-e*row(k), row(j))
+	} else {
+		radC = math.Atan2(e*row(i), row(j))
+	}
+	return float32(radA * RadToTurns), float32(radB * RadToTurns), float32(radC * RadToTurns)
+}
@@ -0,0 +1,103 @@
+package d3dmath
+
+import (
+	"math"
+	"testing"
+)
+
+var eulerOrders = []EulerOrder{
+	EulerXYZ, EulerXZY, EulerYXZ, EulerYZX, EulerZXY, EulerZYX,
+	EulerXYX, EulerXZX, EulerYXY, EulerYZY, EulerZXZ, EulerZYZ,
+}
+
+func TestRotateEulerRotatesAboutAxesInOrder(t *testing.T) {
+	m := RotateRightHandEuler(EulerXYZ, 0.1, 0.2, 0.3)
+	want := Mul4(RotateRightHandX(0.1), RotateRightHandY(0.2), RotateRightHandZ(0.3))
+	checkFloatsNear(t, m[:], want[:]...)
+
+	m = RotateLeftHandEuler(EulerZYZ, 0.1, 0.2, 0.3)
+	want = Mul4(RotateLeftHandZ(0.1), RotateLeftHandY(0.2), RotateLeftHandZ(0.3))
+	checkFloatsNear(t, m[:], want[:]...)
+}
+
+func TestRotateYawPitchRoll(t *testing.T) {
+	m := RotateLeftHandYawPitchRoll(0.1, 0.2, 0.3)
+	q := QuatLeftHandYawPitchRoll(0.1, 0.2, 0.3).ToMat4()
+	checkFloatsNear(t, m[:], q[:]...)
+
+	m = RotateRightHandYawPitchRoll(0.1, 0.2, 0.3)
+	q = QuatRightHandYawPitchRoll(0.1, 0.2, 0.3).ToMat4()
+	checkFloatsNear(t, m[:], q[:]...)
+}
+
+func TestRotateLeftHandYawPitchRollMatchesD3DX(t *testing.T) {
+	// D3DXMatrixRotationYawPitchRoll(Pi/2, Pi/4, Pi/6) returns this matrix.
+	h := float32(math.Sqrt2 / 2)
+	r := float32(math.Sqrt(3) / 2)
+	m := RotateLeftHandYawPitchRoll(0.25, 0.125, 1.0/12)
+	checkFloatsNear(t, m[:],
+		h/2, h/2, -r, 0,
+		r*h, r*h, 0.5, 0,
+		h, -h, 0, 0,
+		0, 0, 0, 1,
+	)
+}
+
+func TestEulerRoundTrip(t *testing.T) {
+	for _, order := range eulerOrders {
+		b := float32(0.2)
+		if eulerAxes[order][0] == eulerAxes[order][2] {
+			b = 0.3
+		}
+		m := RotateRightHandEuler(order, 0.1, b, -0.4)
+		x, y, z := m.RightHandEuler(order)
+		checkFloatsNear(t, []float32{x, y, z}, 0.1, b, -0.4)
+
+		m = RotateLeftHandEuler(order, 0.1, b, -0.4)
+		x, y, z = m.LeftHandEuler(order)
+		checkFloatsNear(t, []float32{x, y, z}, 0.1, b, -0.4)
+	}
+}
+
+func TestEulerIgnoresTranslation(t *testing.T) {
+	m := Mul4(RotateRightHandEuler(EulerYXZ, 0.1, 0.2, 0.3), Translate(1, 2, 3))
+	a, b, c := m.RightHandEuler(EulerYXZ)
+	checkFloatsNear(t, []float32{a, b, c}, 0.1, 0.2, 0.3)
+}
+
+func TestEulerInGimbalLock(t *testing.T) {
+	for _, order := range eulerOrders {
+		locked := []float32{0.25, -0.25}
+		if eulerAxes[order][0] == eulerAxes[order][2] {
+			locked = []float32{0, 0.5}
+		}
+		for _, b := range locked {
+			m := RotateRightHandEuler(order, 0.1, b, 0.15)
+			x, y, z := m.RightHandEuler(order)
+			if x != 0 {
+				t.Errorf("%v: first angle is %v in gimbal lock", order, x)
+			}
+			checkFloatsNear(t, []float32{y}, b)
+			have := RotateRightHandEuler(order, x, y, z)
+			checkFloatsNear(t, have[:], m[:]...)
+
+			m = RotateLeftHandEuler(order, 0.1, b, 0.15)
+			x, y, z = m.LeftHandEuler(order)
+			if x != 0 {
+				t.Errorf("%v: first left-handed angle is %v in gimbal lock", order, x)
+			}
+			checkFloatsNear(t, []float32{y}, b)
+			have = RotateLeftHandEuler(order, x, y, z)
+			checkFloatsNear(t, have[:], m[:]...)
+		}
+	}
+}
+
+func TestEulerOrderString(t *testing.T) {
+	if s := EulerXZY.String(); s != "EulerXZY" {
+		t.Errorf("have %q", s)
+	}
+	if s := EulerOrder(12).String(); s != "EulerOrder(12)" {
+		t.Errorf("have %q", s)
+	}
+}
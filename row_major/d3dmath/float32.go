@@ -0,0 +1,22 @@
+package d3dmath
+
+import "math"
+
+// This file holds the code that depends on the float type. The float64
+// and generic packages have their own versions of it, see internal/gen.
+
+// ulpKey maps the bits of f to an integer that is monotonic in f, so the
+// difference of two keys is their distance in ULPs.
+func ulpKey(f float32) uint64 {
+	const signBit = 1 << 31
+	bits := uint64(math.Float32bits(f))
+	if bits&signBit != 0 {
+		return signBit - (bits &^ signBit)
+	}
+	return signBit + bits
+}
+
+// epsilon returns the difference between 1 and the next larger float32.
+func epsilon() float32 {
+	return 1.0 / (1 << 23)
+}
@@ -0,0 +1,6 @@
+package d3dmath
+
+// nearTolerance returns the absolute tolerance of checkFloatsNear.
+func nearTolerance() float32 {
+	return 1e-5
+}
@@ -0,0 +1,184 @@
+package d3dmath
+
+import (
+	"fmt"
+	"math"
+)
+
+// Containment describes how a point or volume lies relative to a Frustum.
+type Containment int
+
+const (
+	// Outside means the object lies completely outside the frustum.
+	Outside Containment = iota
+	// Intersecting means the object lies partly inside the frustum or touches
+	// its border.
+	Intersecting
+	// Inside means the object lies completely inside the frustum.
+	Inside
+)
+
+func (c Containment) String() string {
+	switch c {
+	case Outside:
+		return "Outside"
+	case Intersecting:
+		return "Intersecting"
+	case Inside:
+		return "Inside"
+	}
+	return fmt.Sprintf("Containment(%d)", int(c))
+}
+
+// Frustum is the view volume of a camera, bounded by the planes left, right,
+// bottom, top, near and far, in that order. The plane normals have length 1
+// and point into the frustum.
+type Frustum [6]Plane
+
+// FrustumFromMat4 extracts the frustum planes from the combined view and
+// projection matrix m, e.g. Mul4(view, projection). The projection is expected
+// to map depth onto the range 0 to 1, like Direct3D and all projections in this
+// package except Ortho, see FrustumFromMat4GL. Reverse depth, which maps the
+// near plane to 1, works as well. If m also contains the world matrix, the
+// frustum is in model space.
+func FrustumFromMat4(m Mat4) Frustum {
+	return frustumFromMat4(m, false)
+}
+
+// FrustumFromMat4GL is like FrustumFromMat4 for projections that map depth
+// onto the range -1 to 1, as OpenGL does. In this package, this is Ortho.
+func FrustumFromMat4GL(m Mat4) Frustum {
+	return frustumFromMat4(m, true)
+}
+
+func frustumFromMat4(m Mat4, minusOneToOne bool) Frustum {
+	// A row vector v is projected to v * m, the columns of m are the factors
+	// for the projected x, y, z and w.
+	x := Vec4{m[0], m[4], m[8], m[12]}
+	y := Vec4{m[1], m[5], m[9], m[13]}
+	z := Vec4{m[2], m[6], m[10], m[14]}
+	w := Vec4{m[3], m[7], m[11], m[15]}
+	near, far := z, w.Sub(z)
+	if minusOneToOne {
+		near = w.Add(z)
+	}
+	// With reverse depth, the two depth planes swap. In a perspective
+	// projection, w is the distance along the view direction, so the near
+	// plane faces in the direction in which w grows and the far plane
+	// against it. The far plane of infinite projections has no direction.
+	view := Vec3{w[0], w[1], w[2]}
+	if (Vec3{near[0], near[1], near[2]}).Dot(view) < 0 ||
+		(Vec3{far[0], far[1], far[2]}).Dot(view) > 0 {
+		near, far = far, near
+	}
+	return Frustum{
+		normalizeFrustumPlane(w.Add(x)),
+		normalizeFrustumPlane(w.Sub(x)),
+		normalizeFrustumPlane(w.Add(y)),
+		normalizeFrustumPlane(w.Sub(y)),
+		normalizeFrustumPlane(near),
+		normalizeFrustumPlane(far),
+	}
+}
+
+func normalizeFrustumPlane(v Vec4) Plane {
+	if v[0] == 0 && v[1] == 0 && v[2] == 0 {
+		// This happens for the far plane of infinite projections. The plane
+		// then contains all points.
+		return Plane(v)
+	}
+	return Plane(v).Normalized()
+}
+
+// ContainsPoint returns Inside if p lies inside f, Intersecting if it lies on
+// the border of f and Outside otherwise.
+func (f Frustum) ContainsPoint(p Vec3) Containment {
+	result := Inside
+	for _, plane := range f {
+		d := plane.DotCoord(p)
+		if d < 0 {
+			return Outside
+		}
+		if d == 0 {
+			result = Intersecting
+		}
+	}
+	return result
+}
+
+// IntersectsSphere returns where s lies relative to f. For spheres near the
+// frustum's corners this is conservative, i.e. it might return Intersecting
+// for spheres that lie just outside.
+func (f Frustum) IntersectsSphere(s Sphere) Containment {
+	result := Inside
+	for _, plane := range f {
+		d := plane.DotCoord(s.Center)
+		if d < -s.Radius {
+			return Outside
+		}
+		if d < s.Radius {
+			result = Intersecting
+		}
+	}
+	return result
+}
+
+// IntersectsAABB returns where b lies relative to f. For boxes near the
+// frustum's corners this is conservative, i.e. it might return Intersecting
+// for boxes that lie just outside.
+func (f Frustum) IntersectsAABB(b AABB) Containment {
+	result := Inside
+	for _, plane := range f {
+		// Check the box corners that lie farthest in the direction of the
+		// plane normal and farthest against it.
+		pos, neg := b.Max, b.Min
+		for i := 0; i < 3; i++ {
+			if plane[i] < 0 {
+				pos[i], neg[i] = neg[i], pos[i]
+			}
+		}
+		if plane.DotCoord(pos) < 0 {
+			return Outside
+		}
+		if plane.DotCoord(neg) < 0 {
+			result = Intersecting
+		}
+	}
+	return result
+}
+
+// Corners returns the eight corners of f. The first four are on the near
+// plane, the last four on the far plane, each in the order left-bottom,
+// right-bottom, left-top, right-top. This can be used e.g. to fit shadow map
+// cascades around the view volume. The far corners are only finite if the far
+// plane is.
+func (f Frustum) Corners() [8]Vec3 {
+	left, right, bottom, top, near, far := f[0], f[1], f[2], f[3], f[4], f[5]
+	return [8]Vec3{
+		intersectPlanes(near, left, bottom),
+		intersectPlanes(near, right, bottom),
+		intersectPlanes(near, left, top),
+		intersectPlanes(near, right, top),
+		intersectPlanes(far, left, bottom),
+		intersectPlanes(far, right, bottom),
+		intersectPlanes(far, left, top),
+		intersectPlanes(far, right, top),
+	}
+}
+
+// intersectPlanes returns the point that lies on all three planes.
+func intersectPlanes(p1, p2, p3 Plane) Vec3 {
+	n1, n2, n3 := p1.Normal(), p2.Normal(), p3.Normal()
+	n23 := n2.Cross(n3)
+	denom := n1.Dot(n23)
+	if denom == 0 {
+		inf := float32(math.Inf(1))
+		return Vec3{inf, inf, inf}
+	}
+	sum := AddVec3(
+		n23.MulScalar(p1[3]),
+		n3.Cross(n1).MulScalar(p2[3]),
+		n1.Cross(n2).MulScalar(p3[3]),
+	)
+	return sum.MulScalar(-1 / denom)
+}
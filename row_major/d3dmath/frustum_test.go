@@ -0,0 +1,143 @@
+package d3dmath
+
+import (
+	"math"
+	"testing"
+)
+
+func testFrustum() Frustum {
+	// The camera is at the origin and looks along the positive z-axis, with a
+	// field of view of 90 degrees, so the frustum is bounded by |x| <= z and
+	// |y| <= z, between z = 1 and z = 10.
+	view := LookAtLH(Vec3{0, 0, 0}, Vec3{0, 0, 1}, Vec3{0, 1, 0})
+	projection := PerspectiveFovLH(math.Pi/2, 1, 1, 10)
+	return FrustumFromMat4(Mul4(view, projection))
+}
+
+func checkContainment(t *testing.T, have, want Containment) {
+	t.Helper()
+	if have != want {
+		t.Errorf("have %v but want %v", have, want)
+	}
+}
+
+func TestFrustumContainsPoint(t *testing.T) {
+	f := testFrustum()
+	checkContainment(t, f.ContainsPoint(Vec3{0, 0, 5}), Inside)
+	checkContainment(t, f.ContainsPoint(Vec3{4, -4, 5}), Inside)
+	checkContainment(t, f.ContainsPoint(Vec3{0, 0, 0.5}), Outside)
+	checkContainment(t, f.ContainsPoint(Vec3{0, 0, 11}), Outside)
+	checkContainment(t, f.ContainsPoint(Vec3{6, 0, 5}), Outside)
+	checkContainment(t, f.ContainsPoint(Vec3{0, -6, 5}), Outside)
+	checkContainment(t, f.ContainsPoint(Vec3{0, 0, -5}), Outside)
+}
+
+func TestFrustumIntersectsSphere(t *testing.T) {
+	f := testFrustum()
+	checkContainment(t, f.IntersectsSphere(Sphere{Vec3{0, 0, 5}, 1}), Inside)
+	checkContainment(t, f.IntersectsSphere(Sphere{Vec3{5.5, 0, 5}, 1}), Intersecting)
+	checkContainment(t, f.IntersectsSphere(Sphere{Vec3{0, 0, 10}, 1}), Intersecting)
+	checkContainment(t, f.IntersectsSphere(Sphere{Vec3{0, 0, -3}, 1}), Outside)
+	checkContainment(t, f.IntersectsSphere(Sphere{Vec3{0, 8, 5}, 1}), Outside)
+}
+
+func TestFrustumIntersectsAABB(t *testing.T) {
+	f := testFrustum()
+	checkContainment(t, f.IntersectsAABB(AABB{Vec3{-1, -1, 4}, Vec3{1, 1, 6}}), Inside)
+	checkContainment(t, f.IntersectsAABB(AABB{Vec3{4, -1, 4}, Vec3{6, 1, 6}}), Intersecting)
+	checkContainment(t, f.IntersectsAABB(AABB{Vec3{-20, -20, 0}, Vec3{20, 20, 20}}), Intersecting)
+	checkContainment(t, f.IntersectsAABB(AABB{Vec3{-1, -1, 11}, Vec3{1, 1, 12}}), Outside)
+	checkContainment(t, f.IntersectsAABB(AABB{Vec3{7, -1, 4}, Vec3{8, 1, 6}}), Outside)
+}
+
+func TestFrustumCorners(t *testing.T) {
+	checkFloatsNear(t, frustumCorners(testFrustum()),
+		-1, -1, 1,
+		1, -1, 1,
+		-1, 1, 1,
+		1, 1, 1,
+		-10, -10, 10,
+		10, -10, 10,
+		-10, 10, 10,
+		10, 10, 10,
+	)
+}
+
+func frustumCorners(f Frustum) []float32 {
+	var floats []float32
+	for _, c := range f.Corners() {
+		floats = append(floats, c[:]...)
+	}
+	return floats
+}
+
+func TestReverseZFrustum(t *testing.T) {
+	view := LookAtLH(Vec3{0, 0, 0}, Vec3{0, 0, 1}, Vec3{0, 1, 0})
+	want := frustumCorners(testFrustum())
+
+	f := FrustumFromMat4(Mul4(view, PerspectiveFovReverseZLH(math.Pi/2, 1, 1, 10)))
+	checkFloatsNear(t, frustumCorners(f), want...)
+	checkContainment(t, f.ContainsPoint(Vec3{0, 0, 5}), Inside)
+	checkContainment(t, f.ContainsPoint(Vec3{0, 0, 0.5}), Outside)
+	checkContainment(t, f.ContainsPoint(Vec3{0, 0, 11}), Outside)
+
+	// The far corners of an infinite frustum are infinitely far away, the
+	// near corners stay the same.
+	f = FrustumFromMat4(Mul4(view, PerspectiveFovInfiniteReverseZLH(math.Pi/2, 1, 1)))
+	corners := frustumCorners(f)
+	checkFloatsNear(t, corners[:12], want[:12]...)
+	for _, c := range corners[12:] {
+		if !math.IsInf(float64(c), 0) {
+			t.Errorf("far corner element is %v", c)
+		}
+	}
+	checkContainment(t, f.ContainsPoint(Vec3{0, 0, 0.5}), Outside)
+	checkContainment(t, f.ContainsPoint(Vec3{0, 0, 1e6}), Inside)
+}
+
+func TestFrustumFromMat4GL(t *testing.T) {
+	// Ortho maps depth onto -1 to 1, the near plane is at z = 1, the far
+	// plane at z = 10.
+	f := FrustumFromMat4GL(Ortho(-2, 2, -2, 2, 1, 10))
+	checkContainment(t, f.ContainsPoint(Vec3{0, 0, 2}), Inside)
+	checkContainment(t, f.ContainsPoint(Vec3{0, 0, 0.5}), Outside)
+	checkContainment(t, f.ContainsPoint(Vec3{0, 0, 11}), Outside)
+	checkContainment(t, f.ContainsPoint(Vec3{3, 0, 2}), Outside)
+	checkFloatsNear(t, frustumCorners(f),
+		-2, -2, 1,
+		2, -2, 1,
+		-2, 2, 1,
+		2, 2, 1,
+		-2, -2, 10,
+		2, -2, 10,
+		-2, 2, 10,
+		2, 2, 10,
+	)
+}
+
+func TestFrustumInWorldSpace(t *testing.T) {
+	// A right-handed camera at 5, 0, 0 looking along the negative x-axis.
+	view := LookAtRH(Vec3{5, 0, 0}, Vec3{0, 0, 0}, Vec3{0, 1, 0})
+	projection := PerspectiveFovRH(math.Pi/2, 2, 1, 10)
+	f := FrustumFromMat4(Mul4(view, projection))
+	checkContainment(t, f.ContainsPoint(Vec3{0, 0, 0}), Inside)
+	checkContainment(t, f.ContainsPoint(Vec3{0, 0, 8}), Inside)
+	checkContainment(t, f.ContainsPoint(Vec3{0, 6, 0}), Outside)
+	checkContainment(t, f.ContainsPoint(Vec3{6, 0, 0}), Outside)
+	checkContainment(t, f.ContainsPoint(Vec3{-6, 0, 0}), Outside)
+}
+
+func TestInfiniteFrustum(t *testing.T) {
+	view := LookAtLH(Vec3{0, 0, 0}, Vec3{0, 0, 1}, Vec3{0, 1, 0})
+	projection := PerspectiveFovInfiniteLH(math.Pi/2, 1, 1)
+	f := FrustumFromMat4(Mul4(view, projection))
+	checkContainment(t, f.ContainsPoint(Vec3{0, 0, 1e6}), Inside)
+	checkContainment(t, f.ContainsPoint(Vec3{0, 0, 0.5}), Outside)
+}
+
+func TestContainmentString(t *testing.T) {
+	checkString(t, Outside.String(), "Outside")
+	checkString(t, Intersecting.String(), "Intersecting")
+	checkString(t, Inside.String(), "Inside")
+	checkString(t, Containment(5).String(), "Containment(5)")
+}
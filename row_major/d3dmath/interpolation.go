@@ -0,0 +1,261 @@
+package d3dmath
+
+import "math"
+
+// Lerp returns the linear interpolation between v and w, like D3DXVec2Lerp. t
+// is 0 for v and 1 for w.
+func (v Vec2) Lerp(w Vec2, t float32) Vec2 {
+	return Vec2{
+		v[0] + t*(w[0]-v[0]),
+		v[1] + t*(w[1]-v[1]),
+	}
+}
+
+// Minimize returns the element-wise minimum of v and w, like D3DXVec2Minimize.
+func (v Vec2) Minimize(w Vec2) Vec2 {
+	return Vec2{min(v[0], w[0]), min(v[1], w[1])}
+}
+
+// Maximize returns the element-wise maximum of v and w, like D3DXVec2Maximize.
+func (v Vec2) Maximize(w Vec2) Vec2 {
+	return Vec2{max(v[0], w[0]), max(v[1], w[1])}
+}
+
+// HermiteVec2 returns the Hermite spline interpolation from position v1 with
+// tangent t1 to position v2 with tangent t2, like D3DXVec2Hermite. s is 0 for
+// v1 and 1 for v2.
+func HermiteVec2(v1, t1, v2, t2 Vec2, s float32) (h Vec2) {
+	h1, h2, h3, h4 := hermiteBasis(s)
+	for i := range h {
+		h[i] = h1*v1[i] + h2*t1[i] + h3*v2[i] + h4*t2[i]
+	}
+	return
+}
+
+// CatmullRomVec2 returns the Catmull-Rom spline interpolation between v1 and
+// v2, where v0 and v3 are the points before v1 and after v2, like
+// D3DXVec2CatmullRom. s is 0 for v1 and 1 for v2.
+func CatmullRomVec2(v0, v1, v2, v3 Vec2, s float32) (c Vec2) {
+	c0, c1, c2, c3 := catmullRomBasis(s)
+	for i := range c {
+		c[i] = c0*v0[i] + c1*v1[i] + c2*v2[i] + c3*v3[i]
+	}
+	return
+}
+
+// BaryCentricVec2 returns the point v1 + f*(v2-v1) + g*(v3-v1) in the plane of
+// the triangle v1, v2, v3, like D3DXVec2BaryCentric.
+func BaryCentricVec2(v1, v2, v3 Vec2, f, g float32) (b Vec2) {
+	for i := range b {
+		b[i] = v1[i] + f*(v2[i]-v1[i]) + g*(v3[i]-v1[i])
+	}
+	return
+}
+
+// Lerp returns the linear interpolation between v and w, like D3DXVec3Lerp. t
+// is 0 for v and 1 for w.
+func (v Vec3) Lerp(w Vec3, t float32) Vec3 {
+	return Vec3{
+		v[0] + t*(w[0]-v[0]),
+		v[1] + t*(w[1]-v[1]),
+		v[2] + t*(w[2]-v[2]),
+	}
+}
+
+// Slerp returns the spherical linear interpolation between the unit vectors v
+// and w. t is 0 for v and 1 for w. The result is a unit vector that rotates
+// from v to w with constant angular velocity. If v and w point in exactly
+// opposite directions, the rotation axis is an arbitrary axis perpendicular to
+// v.
+func (v Vec3) Slerp(w Vec3, t float32) Vec3 {
+	// Computing in float64 keeps the result accurate for nearly opposite
+	// vectors, where the weights of v and w get large and cancel out.
+	var a, b [3]float64
+	for i := range a {
+		a[i], b[i] = float64(v[i]), float64(w[i])
+	}
+	cos := a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+	cross := [3]float64{
+		a[1]*b[2] - a[2]*b[1],
+		a[2]*b[0] - a[0]*b[2],
+		a[0]*b[1] - a[1]*b[0],
+	}
+	sin := math.Sqrt(cross[0]*cross[0] + cross[1]*cross[1] + cross[2]*cross[2])
+	theta := math.Atan2(sin, cos)
+	if sin > 16*float64(epsilon()) {
+		wv := math.Sin((1-float64(t))*theta) / sin
+		ww := math.Sin(float64(t)*theta) / sin
+		return Vec3{
+			float32(wv*a[0] + ww*b[0]),
+			float32(wv*a[1] + ww*b[1]),
+			float32(wv*a[2] + ww*b[2]),
+		}
+	}
+	if cos > 0 {
+		return v.Lerp(w, t).Normalized()
+	}
+	// w is about -v and the plane of rotation is not well defined. Use the
+	// part of w perpendicular to v if there is one. Otherwise, any direction
+	// perpendicular to v will do, cross v with the axis it is least aligned
+	// with.
+	perp := [3]float64{
+		cross[1]*a[2] - cross[2]*a[1],
+		cross[2]*a[0] - cross[0]*a[2],
+		cross[0]*a[1] - cross[1]*a[0],
+	}
+	if cross == [3]float64{} {
+		if a[0]*a[0] > 0.5 {
+			perp = [3]float64{-a[2], 0, a[0]}
+		} else {
+			perp = [3]float64{0, a[2], -a[1]}
+		}
+	}
+	n := math.Sqrt(perp[0]*perp[0] + perp[1]*perp[1] + perp[2]*perp[2])
+	s, c := math.Sincos(float64(t) * theta)
+	return Vec3{
+		float32(c*a[0] + s*perp[0]/n),
+		float32(c*a[1] + s*perp[1]/n),
+		float32(c*a[2] + s*perp[2]/n),
+	}
+}
+
+// Minimize returns the element-wise minimum of v and w, like D3DXVec3Minimize.
+func (v Vec3) Minimize(w Vec3) Vec3 {
+	return Vec3{min(v[0], w[0]), min(v[1], w[1]), min(v[2], w[2])}
+}
+
+// Maximize returns the element-wise maximum of v and w, like D3DXVec3Maximize.
+func (v Vec3) Maximize(w Vec3) Vec3 {
+	return Vec3{max(v[0], w[0]), max(v[1], w[1]), max(v[2], w[2])}
+}
+
+// HermiteVec3 returns the Hermite spline interpolation from position v1 with
+// tangent t1 to position v2 with tangent t2, like D3DXVec3Hermite. s is 0 for
+// v1 and 1 for v2.
+func HermiteVec3(v1, t1, v2, t2 Vec3, s float32) (h Vec3) {
+	h1, h2, h3, h4 := hermiteBasis(s)
+	for i := range h {
+		h[i] = h1*v1[i] + h2*t1[i] + h3*v2[i] + h4*t2[i]
+	}
+	return
+}
+
+// CatmullRomVec3 returns the Catmull-Rom spline interpolation between v1 and
+// v2, where v0 and v3 are the points before v1 and after v2, like
+// D3DXVec3CatmullRom. s is 0 for v1 and 1 for v2.
+func CatmullRomVec3(v0, v1, v2, v3 Vec3, s float32) (c Vec3) {
+	c0, c1, c2, c3 := catmullRomBasis(s)
+	for i := range c {
+		c[i] = c0*v0[i] + c1*v1[i] + c2*v2[i] + c3*v3[i]
+	}
+	return
+}
+
+// BaryCentricVec3 returns the point v1 + f*(v2-v1) + g*(v3-v1) in the plane of
+// the triangle v1, v2, v3, like D3DXVec3BaryCentric.
+func BaryCentricVec3(v1, v2, v3 Vec3, f, g float32) (b Vec3) {
+	for i := range b {
+		b[i] = v1[i] + f*(v2[i]-v1[i]) + g*(v3[i]-v1[i])
+	}
+	return
+}
+
+// Lerp returns the linear interpolation between v and w, like D3DXVec4Lerp. t
+// is 0 for v and 1 for w.
+func (v Vec4) Lerp(w Vec4, t float32) Vec4 {
+	return Vec4{
+		v[0] + t*(w[0]-v[0]),
+		v[1] + t*(w[1]-v[1]),
+		v[2] + t*(w[2]-v[2]),
+		v[3] + t*(w[3]-v[3]),
+	}
+}
+
+// Minimize returns the element-wise minimum of v and w, like D3DXVec4Minimize.
+func (v Vec4) Minimize(w Vec4) Vec4 {
+	return Vec4{
+		min(v[0], w[0]),
+		min(v[1], w[1]),
+		min(v[2], w[2]),
+		min(v[3], w[3]),
+	}
+}
+
+// Maximize returns the element-wise maximum of v and w, like D3DXVec4Maximize.
+func (v Vec4) Maximize(w Vec4) Vec4 {
+	return Vec4{
+		max(v[0], w[0]),
+		max(v[1], w[1]),
+		max(v[2], w[2]),
+		max(v[3], w[3]),
+	}
+}
+
+// HermiteVec4 returns the Hermite spline interpolation from position v1 with
+// tangent t1 to position v2 with tangent t2, like D3DXVec4Hermite. s is 0 for
+// v1 and 1 for v2.
+func HermiteVec4(v1, t1, v2, t2 Vec4, s float32) (h Vec4) {
+	h1, h2, h3, h4 := hermiteBasis(s)
+	for i := range h {
+		h[i] = h1*v1[i] + h2*t1[i] + h3*v2[i] + h4*t2[i]
+	}
+	return
+}
+
+// CatmullRomVec4 returns the Catmull-Rom spline interpolation between v1 and
+// v2, where v0 and v3 are the points before v1 and after v2, like
+// D3DXVec4CatmullRom. s is 0 for v1 and 1 for v2.
+func CatmullRomVec4(v0, v1, v2, v3 Vec4, s float32) (c Vec4) {
+	c0, c1, c2, c3 := catmullRomBasis(s)
+	for i := range c {
+		c[i] = c0*v0[i] + c1*v1[i] + c2*v2[i] + c3*v3[i]
+	}
+	return
+}
+
+// BaryCentricVec4 returns the point v1 + f*(v2-v1) + g*(v3-v1) in the plane of
+// the triangle v1, v2, v3, like D3DXVec4BaryCentric.
+func BaryCentricVec4(v1, v2, v3 Vec4, f, g float32) (b Vec4) {
+	for i := range b {
+		b[i] = v1[i] + f*(v2[i]-v1[i]) + g*(v3[i]-v1[i])
+	}
+	return
+}
+
+// hermiteBasis returns the weights of the start position, start tangent, end
+// position and end tangent of a Hermite spline at s.
+func hermiteBasis(s float32) (h1, h2, h3, h4 float32) {
+	s2 := s * s
+	s3 := s2 * s
+	h1 = 2*s3 - 3*s2 + 1
+	h2 = s3 - 2*s2 + s
+	h3 = -2*s3 + 3*s2
+	h4 = s3 - s2
+	return
+}
+
+// catmullRomBasis returns the weights of the four control points of a
+// Catmull-Rom spline at s.
+func catmullRomBasis(s float32) (c0, c1, c2, c3 float32) {
+	s2 := s * s
+	s3 := s2 * s
+	c0 = 0.5 * (-s3 + 2*s2 - s)
+	c1 = 0.5 * (3*s3 - 5*s2 + 2)
+	c2 = 0.5 * (-3*s3 + 4*s2 + s)
+	c3 = 0.5 * (s3 - s2)
+	return
+}
+
+func min(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b float32) float32 {
+	if a > b {
+		return a
+	}
+	return b
+}
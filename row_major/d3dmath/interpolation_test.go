@@ -0,0 +1,125 @@
+package d3dmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLerp(t *testing.T) {
+	v2 := Vec2{1, 2}.Lerp(Vec2{3, 6}, 0.25)
+	checkFloats(t, v2[:], 1.5, 3)
+	v3 := Vec3{1, 2, 3}.Lerp(Vec3{3, 6, -1}, 0.25)
+	checkFloats(t, v3[:], 1.5, 3, 2)
+	v4 := Vec4{1, 2, 3, 4}.Lerp(Vec4{3, 6, -1, 4}, 0.25)
+	checkFloats(t, v4[:], 1.5, 3, 2, 4)
+}
+
+func TestMinimizeMaximize(t *testing.T) {
+	min2 := Vec2{1, 5}.Minimize(Vec2{2, -3})
+	checkFloats(t, min2[:], 1, -3)
+	max2 := Vec2{1, 5}.Maximize(Vec2{2, -3})
+	checkFloats(t, max2[:], 2, 5)
+
+	min3 := Vec3{1, 5, 0}.Minimize(Vec3{2, -3, 0})
+	checkFloats(t, min3[:], 1, -3, 0)
+	max3 := Vec3{1, 5, 0}.Maximize(Vec3{2, -3, 0})
+	checkFloats(t, max3[:], 2, 5, 0)
+
+	min4 := Vec4{1, 5, 0, -7}.Minimize(Vec4{2, -3, 0, 7})
+	checkFloats(t, min4[:], 1, -3, 0, -7)
+	max4 := Vec4{1, 5, 0, -7}.Maximize(Vec4{2, -3, 0, 7})
+	checkFloats(t, max4[:], 2, 5, 0, 7)
+}
+
+func TestHermite(t *testing.T) {
+	v1, t1 := Vec3{0, 0, 0}, Vec3{1, 0, 0}
+	v2, t2 := Vec3{1, 1, 0}, Vec3{0, 1, 0}
+	start := HermiteVec3(v1, t1, v2, t2, 0)
+	checkFloats(t, start[:], v1[:]...)
+	end := HermiteVec3(v1, t1, v2, t2, 1)
+	checkFloats(t, end[:], v2[:]...)
+	// At s = 0.5 the weights are 1/2, 1/8, 1/2 and -1/8.
+	mid := HermiteVec3(v1, t1, v2, t2, 0.5)
+	checkFloats(t, mid[:], 0.625, 0.375, 0)
+
+	mid2 := HermiteVec2(Vec2{0, 0}, Vec2{1, 0}, Vec2{1, 1}, Vec2{0, 1}, 0.5)
+	checkFloats(t, mid2[:], 0.625, 0.375)
+	mid4 := HermiteVec4(
+		Vec4{0, 0, 0, 2}, Vec4{1, 0, 0, 0},
+		Vec4{1, 1, 0, 2}, Vec4{0, 1, 0, 0},
+		0.5,
+	)
+	checkFloats(t, mid4[:], 0.625, 0.375, 0, 2)
+}
+
+func TestCatmullRom(t *testing.T) {
+	// Catmull-Rom splines through equally spaced points on a line stay on
+	// that line.
+	c2 := CatmullRomVec2(Vec2{0, 0}, Vec2{1, 2}, Vec2{2, 4}, Vec2{3, 6}, 0.5)
+	checkFloats(t, c2[:], 1.5, 3)
+	c3 := CatmullRomVec3(
+		Vec3{0, 0, 0}, Vec3{1, 2, 3}, Vec3{2, 4, 6}, Vec3{3, 6, 9}, 0.25,
+	)
+	checkFloats(t, c3[:], 1.25, 2.5, 3.75)
+	c4 := CatmullRomVec4(
+		Vec4{0, 0, 0, 1}, Vec4{1, 2, 3, 1}, Vec4{2, 4, 6, 1}, Vec4{3, 6, 9, 1},
+		0.5,
+	)
+	checkFloats(t, c4[:], 1.5, 3, 4.5, 1)
+
+	// The spline passes through the two middle points.
+	v0, v1, v2, v3 := Vec3{0, 0, 0}, Vec3{1, 3, 0}, Vec3{2, -1, 5}, Vec3{7, 0, 1}
+	start := CatmullRomVec3(v0, v1, v2, v3, 0)
+	checkFloats(t, start[:], v1[:]...)
+	end := CatmullRomVec3(v0, v1, v2, v3, 1)
+	checkFloats(t, end[:], v2[:]...)
+}
+
+func TestBaryCentric(t *testing.T) {
+	b2 := BaryCentricVec2(Vec2{0, 0}, Vec2{4, 0}, Vec2{0, 8}, 0.25, 0.5)
+	checkFloats(t, b2[:], 1, 4)
+	b3 := BaryCentricVec3(Vec3{0, 0, 1}, Vec3{4, 0, 1}, Vec3{0, 8, 1}, 0.25, 0.5)
+	checkFloats(t, b3[:], 1, 4, 1)
+	b4 := BaryCentricVec4(
+		Vec4{0, 0, 1, 1}, Vec4{4, 0, 1, 1}, Vec4{0, 8, 1, 1}, 0.25, 0.5,
+	)
+	checkFloats(t, b4[:], 1, 4, 1, 1)
+}
+
+func TestVec3Slerp(t *testing.T) {
+	x, y := Vec3{1, 0, 0}, Vec3{0, 1, 0}
+	v := x.Slerp(y, 1.0/3)
+	checkFloatsNear(t, v[:], float32(math.Sqrt(3)/2), 0.5, 0)
+	v = x.Slerp(y, 0)
+	checkFloatsNear(t, v[:], x[:]...)
+	v = x.Slerp(y, 1)
+	checkFloatsNear(t, v[:], y[:]...)
+
+	// Almost parallel vectors.
+	w := Vec3{1, 0.001, 0}.Normalized()
+	v = x.Slerp(w, 0.5)
+	want := Vec3{1, 0.0005, 0}.Normalized()
+	checkFloatsNear(t, v[:], want[:]...)
+
+	// Nearly opposite vectors still rotate in the plane of v and w.
+	w = Vec3{-1, 0.03, 0}.Normalized()
+	v = x.Slerp(w, 1)
+	checkFloatsNear(t, v[:], w[:]...)
+	v = x.Slerp(w, 0.5)
+	half := math.Atan2(0.03, -1) / 2
+	checkFloatsNear(t, v[:], float32(math.Cos(half)), float32(math.Sin(half)), 0)
+	w = Vec3{-1, 0, 1e-7}
+	v = x.Slerp(w, 0.5)
+	checkFloatsNear(t, v[:], 0, 0, 1)
+	v = x.Slerp(w, 1)
+	checkFloatsNear(t, v[:], w[:]...)
+
+	// Opposite vectors rotate through some direction perpendicular to both.
+	for _, v0 := range []Vec3{x, y, {0, 0, 1}, Vec3{1, 1, 1}.Normalized()} {
+		opposite := v0.Negate()
+		mid := v0.Slerp(opposite, 0.5)
+		checkFloatsNear(t, []float32{mid.Norm(), mid.Dot(v0)}, 1, 0)
+		end := v0.Slerp(opposite, 1)
+		checkFloatsNear(t, end[:], opposite[:]...)
+	}
+}
@@ -0,0 +1,21 @@
+package d3dmath
+
+import "github.com/gonutz/d3dmath/internal/simd"
+
+// This file holds the code that depends on the memory layout of the matrices.
+// Package column_major/d3dmath has its own version of it, all other files
+// there are generated from this package, see internal/gen.
+
+// mulSIMD returns the product of m * n, computed with SIMD instructions.
+func mulSIMD(m, n *Mat4) Mat4 {
+	var product Mat4
+	// The rows of the product are the rows of m transformed by n.
+	simd.Transform(product[:], 4, m[:], 4, 4, (*[16]float32)(n))
+	return product
+}
+
+// rowMajor returns the elements of m in row-major order, which package simd
+// expects.
+func (m *Mat4) rowMajor() *[16]float32 {
+	return (*[16]float32)(m)
+}
@@ -0,0 +1,127 @@
+package d3dmath
+
+import (
+	"fmt"
+	"math"
+)
+
+// Plane is the plane of all points x, y, z for which a*x + b*y + c*z + d = 0,
+// like D3DXPLANE. The elements are called a, b, c, d in the docs. The vector
+// a, b, c is the normal of the plane.
+type Plane [4]float32
+
+// PlaneFromPointNormal returns the plane that contains point and is
+// perpendicular to normal, like D3DXPlaneFromPointNormal.
+func PlaneFromPointNormal(point, normal Vec3) Plane {
+	return Plane{normal[0], normal[1], normal[2], -point.Dot(normal)}
+}
+
+// PlaneFromPoints returns the plane that contains the three given points, like
+// D3DXPlaneFromPoints. The normal has length 1 and points to the side from
+// which v1, v2, v3 appear in clockwise order in a left-handed coordinate
+// system.
+func PlaneFromPoints(v1, v2, v3 Vec3) Plane {
+	normal := v2.Sub(v1).Cross(v3.Sub(v1)).Normalized()
+	return PlaneFromPointNormal(v1, normal)
+}
+
+// Normal returns the normal vector a, b, c of p.
+func (p Plane) Normal() Vec3 {
+	return Vec3{p[0], p[1], p[2]}
+}
+
+// Dot returns the dot-product of p and the 4-element vector v, like
+// D3DXPlaneDot.
+func (p Plane) Dot(v Vec4) float32 {
+	return p[0]*v[0] + p[1]*v[1] + p[2]*v[2] + p[3]*v[3]
+}
+
+// DotCoord returns the dot-product of p and the point v with an implicit w of
+// 1, like D3DXPlaneDotCoord. If p is normalized, this is the signed distance
+// of v to the plane, positive on the side that the normal points to.
+func (p Plane) DotCoord(v Vec3) float32 {
+	return p[0]*v[0] + p[1]*v[1] + p[2]*v[2] + p[3]
+}
+
+// DotNormal returns the dot-product of the normal of p and the direction v,
+// like D3DXPlaneDotNormal.
+func (p Plane) DotNormal(v Vec3) float32 {
+	return p[0]*v[0] + p[1]*v[1] + p[2]*v[2]
+}
+
+// Normalized returns a copy of p scaled so that its normal has length 1, like
+// D3DXPlaneNormalize. If the normal has length 0, the zero plane is returned.
+func (p Plane) Normalized() Plane {
+	norm := float32(math.Sqrt(float64(p.DotNormal(p.Normal()))))
+	if norm == 0 {
+		return Plane{}
+	}
+	f := 1 / norm
+	return Plane{f * p[0], f * p[1], f * p[2], f * p[3]}
+}
+
+// IntersectLine returns the point where the infinite line through v1 and v2
+// intersects p, like D3DXPlaneIntersectLine. If the line is parallel to the
+// plane, ok is false.
+func (p Plane) IntersectLine(v1, v2 Vec3) (intersection Vec3, ok bool) {
+	dir := v2.Sub(v1)
+	denom := p.DotNormal(dir)
+	if denom == 0 {
+		return Vec3{}, false
+	}
+	return v1.Sub(dir.MulScalar(p.DotCoord(v1) / denom)), true
+}
+
+// Transformed returns the plane that contains all points of p transformed by
+// m. It uses the inverse transpose of m, so for transforming many planes by
+// the same matrix, TransformInverseTranspose is faster. If m is singular, ok
+// is false. The result is not normalized.
+func (p Plane) Transformed(m Mat4) (transformed Plane, ok bool) {
+	inv, ok := m.Inverse()
+	if !ok {
+		return p, false
+	}
+	return p.TransformInverseTranspose(inv.Transposed()), true
+}
+
+// TransformInverseTranspose transforms p by the inverse transpose of a
+// transformation matrix, like D3DXPlaneTransform. The result is the plane
+// that contains all points of p transformed by the original matrix. It is not
+// normalized.
+func (p Plane) TransformInverseTranspose(inverseTranspose Mat4) Plane {
+	return Plane(Vec4(p).MulMat(inverseTranspose))
+}
+
+// Reflect returns a matrix that reflects points about the plane p, like
+// D3DXMatrixReflect. Reflecting twice gives the identity.
+func Reflect(p Plane) Mat4 {
+	p = p.Normalized()
+	a, b, c, d := p[0], p[1], p[2], p[3]
+	return Mat4{
+		1 - 2*a*a, -2 * b * a, -2 * c * a, 0,
+		-2 * a * b, 1 - 2*b*b, -2 * c * b, 0,
+		-2 * a * c, -2 * b * c, 1 - 2*c*c, 0,
+		-2 * a * d, -2 * b * d, -2 * c * d, 1,
+	}
+}
+
+// Shadow returns a matrix that flattens geometry onto the plane p, as seen
+// from the light, like D3DXMatrixShadow. If the w element of light is 0, it
+// is a directional light shining from direction x, y, z towards the origin,
+// otherwise it is a point light at x, y, z.
+func Shadow(light Vec4, p Plane) Mat4 {
+	p = p.Normalized()
+	d := p.Dot(light)
+	a, b, c, e := p[0], p[1], p[2], p[3]
+	x, y, z, w := light[0], light[1], light[2], light[3]
+	return Mat4{
+		d - a*x, -a * y, -a * z, -a * w,
+		-b * x, d - b*y, -b * z, -b * w,
+		-c * x, -c * y, d - c*z, -c * w,
+		-e * x, -e * y, -e * z, d - e*w,
+	}
+}
+
+func (p Plane) String() string {
+	return fmt.Sprintf("(%.2f %.2f %.2f %.2f)", p[0], p[1], p[2], p[3])
+}
@@ -0,0 +1,143 @@
+package d3dmath
+
+import "testing"
+
+func TestPlaneFromPointNormal(t *testing.T) {
+	p := PlaneFromPointNormal(Vec3{1, 2, 3}, Vec3{0, 0, 2})
+	checkFloats(t, p[:], 0, 0, 2, -6)
+}
+
+func TestPlaneFromPoints(t *testing.T) {
+	p := PlaneFromPoints(Vec3{0, 0, 0}, Vec3{1, 0, 0}, Vec3{0, 1, 0})
+	checkFloats(t, p[:], 0, 0, 1, 0)
+	p = PlaneFromPoints(Vec3{0, 3, 0}, Vec3{0, 3, 1}, Vec3{1, 3, 0})
+	checkFloats(t, p[:], 0, 1, 0, -3)
+}
+
+func TestPlaneNormal(t *testing.T) {
+	n := Plane{1, 2, 3, 4}.Normal()
+	checkFloats(t, n[:], 1, 2, 3)
+}
+
+func TestPlaneDot(t *testing.T) {
+	p := Plane{1, 2, 3, 4}
+	checkFloat(t, p.Dot(Vec4{2, 3, 4, 5}), 2+6+12+20)
+	checkFloat(t, p.DotCoord(Vec3{2, 3, 4}), 2+6+12+4)
+	checkFloat(t, p.DotNormal(Vec3{2, 3, 4}), 2+6+12)
+}
+
+func TestPlaneNormalized(t *testing.T) {
+	p := Plane{0, 3, 4, 10}.Normalized()
+	checkFloats(t, p[:], 0, 0.6, 0.8, 2)
+	p = Plane{0, 0, 0, 1}.Normalized()
+	checkFloats(t, p[:], 0, 0, 0, 0)
+}
+
+func TestPlaneIntersectLine(t *testing.T) {
+	// The plane y = 2.
+	p := Plane{0, 1, 0, -2}
+	v, ok := p.IntersectLine(Vec3{0, 0, 0}, Vec3{1, 1, 0})
+	if !ok {
+		t.Error("line should intersect plane")
+	}
+	checkFloats(t, v[:], 2, 2, 0)
+	// The line extends beyond its two points.
+	v, ok = p.IntersectLine(Vec3{0, 5, 1}, Vec3{0, 4, 1})
+	if !ok {
+		t.Error("line should intersect plane")
+	}
+	checkFloats(t, v[:], 0, 2, 1)
+	_, ok = p.IntersectLine(Vec3{0, 0, 0}, Vec3{1, 0, 1})
+	if ok {
+		t.Error("parallel line should not intersect plane")
+	}
+}
+
+func TestPlaneTransformed(t *testing.T) {
+	p := Plane{0, 1, 0, 0}
+	moved, ok := p.Transformed(Translate(0, 5, 0))
+	if !ok {
+		t.Error("matrix should be invertible")
+	}
+	checkFloats(t, moved[:], 0, 1, 0, -5)
+
+	m := Mul4(
+		Scale(2, 3, 0.5),
+		RotateRightHandAbout(Vec3{1, 2, 3}, 0.2),
+		Translate(1, -2, 3),
+	)
+	p = PlaneFromPoints(Vec3{1, 2, 3}, Vec3{4, -1, 2}, Vec3{0, 5, -3})
+	transformed, ok := p.Transformed(m)
+	if !ok {
+		t.Error("matrix should be invertible")
+	}
+	transform := func(v Vec3) Vec3 {
+		return v.Homogeneous().MulMat(m).ByW()
+	}
+	// Points on the plane stay on the transformed plane.
+	for _, v := range []Vec3{{1, 2, 3}, {4, -1, 2}, {0, 5, -3}} {
+		d := transformed.DotCoord(transform(v))
+		checkFloatsNear(t, []float32{d}, 0)
+	}
+	// Points in front of the plane stay in front of it.
+	front := Vec3{1, 2, 3}.Add(p.Normal())
+	if transformed.DotCoord(transform(front)) <= 0 {
+		t.Error("plane was flipped")
+	}
+
+	inv, _ := m.Inverse()
+	same := p.TransformInverseTranspose(inv.Transposed())
+	checkFloats(t, same[:], transformed[:]...)
+
+	_, ok = p.Transformed(Scale(1, 0, 1))
+	if ok {
+		t.Error("singular matrix was inverted")
+	}
+}
+
+func TestReflect(t *testing.T) {
+	// The plane y = 2, not normalized.
+	m := Reflect(Plane{0, 2, 0, -4})
+	v := Vec3{1, 5, 3}.Homogeneous().MulMat(m).ByW()
+	checkFloatsNear(t, v[:], 1, -1, 3)
+
+	p := PlaneFromPoints(Vec3{1, 2, 3}, Vec3{4, -1, 2}, Vec3{0, 5, -3})
+	m = Reflect(p)
+	twice := m.Mul(m)
+	id := Identity4()
+	checkFloatsNear(t, twice[:], id[:]...)
+
+	for _, v := range []Vec3{{1, 2, 3}, {4, -1, 2}, {-7, 3, 0.5}} {
+		r := v.Homogeneous().MulMat(m).ByW()
+		// Reflected points are as far from the plane, on the other side.
+		d := p.DotCoord(v) + p.DotCoord(r)
+		checkFloatsNear(t, []float32{d}, 0)
+	}
+}
+
+func TestShadow(t *testing.T) {
+	ground := Plane{0, 1, 0, 0}
+	m := Shadow(Vec4{0, 10, 0, 1}, ground)
+	v := Vec3{1, 5, 2}.Homogeneous().MulMat(m).ByW()
+	// The ray from the light through (1,5,2) hits the ground at (2,0,4).
+	checkFloatsNear(t, v[:], 2, 0, 4)
+
+	m = Shadow(Vec4{1, 1, 0, 0}, ground)
+	v = Vec3{0, 3, 7}.Homogeneous().MulMat(m).ByW()
+	checkFloatsNear(t, v[:], -3, 0, 7)
+
+	p := PlaneFromPoints(Vec3{1, 2, 3}, Vec3{4, -1, 2}, Vec3{0, 5, -3})
+	for _, light := range []Vec4{{20, 30, -10, 1}, {1, 2, 3, 0}} {
+		m := Shadow(light, p)
+		for _, v := range []Vec3{{1, 2, 3}, {4, -1, 2}, {-7, 3, 0.5}} {
+			s := v.Homogeneous().MulMat(m).ByW()
+			d := p.DotCoord(s)
+			checkFloatsNear(t, []float32{d}, 0)
+		}
+	}
+}
+
+func TestPlaneString(t *testing.T) {
+	p := Plane{1, 2, 3, 4}
+	checkString(t, p.String(), "(1.00 2.00 3.00 4.00)")
+}
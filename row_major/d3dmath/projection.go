@@ -0,0 +1,299 @@
+package d3dmath
+
+import "math"
+
+// PerspectiveFovLH returns a left-handed perspective projection matrix like
+// D3DXMatrixPerspectiveFovLH. fovYRadians is the field of view in y-direction
+// and aspect is the ratio of width to height of the view. Depth is mapped from
+// near to far onto the range 0 to 1. This is the same as Perspective.
+func PerspectiveFovLH(fovYRadians, aspect, near, far float32) Mat4 {
+	yScale := 1 / float32(math.Tan(float64(fovYRadians)/2))
+	xScale := yScale / aspect
+	dz := far - near
+	return Mat4{
+		xScale, 0, 0, 0,
+		0, yScale, 0, 0,
+		0, 0, far / dz, 1,
+		0, 0, -near * far / dz, 0,
+	}
+}
+
+// PerspectiveFovRH returns a right-handed perspective projection matrix like
+// D3DXMatrixPerspectiveFovRH. fovYRadians is the field of view in y-direction
+// and aspect is the ratio of width to height of the view. Depth is mapped from
+// near to far onto the range 0 to 1.
+func PerspectiveFovRH(fovYRadians, aspect, near, far float32) Mat4 {
+	yScale := 1 / float32(math.Tan(float64(fovYRadians)/2))
+	xScale := yScale / aspect
+	dz := near - far
+	return Mat4{
+		xScale, 0, 0, 0,
+		0, yScale, 0, 0,
+		0, 0, far / dz, -1,
+		0, 0, near * far / dz, 0,
+	}
+}
+
+// PerspectiveLH returns a left-handed perspective projection matrix like
+// D3DXMatrixPerspectiveLH. width and height are the size of the view volume at
+// the near plane.
+func PerspectiveLH(width, height, near, far float32) Mat4 {
+	dz := far - near
+	return Mat4{
+		2 * near / width, 0, 0, 0,
+		0, 2 * near / height, 0, 0,
+		0, 0, far / dz, 1,
+		0, 0, -near * far / dz, 0,
+	}
+}
+
+// PerspectiveRH returns a right-handed perspective projection matrix like
+// D3DXMatrixPerspectiveRH. width and height are the size of the view volume at
+// the near plane.
+func PerspectiveRH(width, height, near, far float32) Mat4 {
+	dz := near - far
+	return Mat4{
+		2 * near / width, 0, 0, 0,
+		0, 2 * near / height, 0, 0,
+		0, 0, far / dz, -1,
+		0, 0, near * far / dz, 0,
+	}
+}
+
+// PerspectiveOffCenterLH returns a customized left-handed perspective
+// projection matrix like D3DXMatrixPerspectiveOffCenterLH. left, right, bottom
+// and top are the borders of the view volume at the near plane.
+func PerspectiveOffCenterLH(left, right, bottom, top, near, far float32) Mat4 {
+	dz := far - near
+	return Mat4{
+		2 * near / (right - left), 0, 0, 0,
+		0, 2 * near / (top - bottom), 0, 0,
+		(left + right) / (left - right), (top + bottom) / (bottom - top), far / dz, 1,
+		0, 0, -near * far / dz, 0,
+	}
+}
+
+// PerspectiveOffCenterRH returns a customized right-handed perspective
+// projection matrix like D3DXMatrixPerspectiveOffCenterRH. left, right, bottom
+// and top are the borders of the view volume at the near plane.
+func PerspectiveOffCenterRH(left, right, bottom, top, near, far float32) Mat4 {
+	dz := near - far
+	return Mat4{
+		2 * near / (right - left), 0, 0, 0,
+		0, 2 * near / (top - bottom), 0, 0,
+		(left + right) / (right - left), (top + bottom) / (top - bottom), far / dz, -1,
+		0, 0, near * far / dz, 0,
+	}
+}
+
+// OrthoLH returns a left-handed orthographic projection matrix like
+// D3DXMatrixOrthoLH. width and height are the size of the view volume. Depth is
+// mapped from near to far onto the range 0 to 1.
+func OrthoLH(width, height, near, far float32) Mat4 {
+	return Mat4{
+		2 / width, 0, 0, 0,
+		0, 2 / height, 0, 0,
+		0, 0, 1 / (far - near), 0,
+		0, 0, near / (near - far), 1,
+	}
+}
+
+// OrthoRH returns a right-handed orthographic projection matrix like
+// D3DXMatrixOrthoRH. width and height are the size of the view volume. Depth is
+// mapped from near to far onto the range 0 to 1.
+func OrthoRH(width, height, near, far float32) Mat4 {
+	return Mat4{
+		2 / width, 0, 0, 0,
+		0, 2 / height, 0, 0,
+		0, 0, 1 / (near - far), 0,
+		0, 0, near / (near - far), 1,
+	}
+}
+
+// OrthoOffCenterLH returns a customized left-handed orthographic projection
+// matrix like D3DXMatrixOrthoOffCenterLH. left, right, bottom and top are the
+// borders of the view volume.
+func OrthoOffCenterLH(left, right, bottom, top, near, far float32) Mat4 {
+	return Mat4{
+		2 / (right - left), 0, 0, 0,
+		0, 2 / (top - bottom), 0, 0,
+		0, 0, 1 / (far - near), 0,
+		(left + right) / (left - right), (top + bottom) / (bottom - top), near / (near - far), 1,
+	}
+}
+
+// OrthoOffCenterRH returns a customized right-handed orthographic projection
+// matrix like D3DXMatrixOrthoOffCenterRH. left, right, bottom and top are the
+// borders of the view volume.
+func OrthoOffCenterRH(left, right, bottom, top, near, far float32) Mat4 {
+	return Mat4{
+		2 / (right - left), 0, 0, 0,
+		0, 2 / (top - bottom), 0, 0,
+		0, 0, 1 / (near - far), 0,
+		(left + right) / (left - right), (top + bottom) / (bottom - top), near / (near - far), 1,
+	}
+}
+
+// PerspectiveFovReverseZLH is like PerspectiveFovLH but maps depth in reverse,
+// the near plane to 1 and the far plane to 0. Together with a floating point
+// depth buffer and the depth test GREATER this spreads the depth precision more
+// evenly and reduces z-fighting in large scenes.
+func PerspectiveFovReverseZLH(fovYRadians, aspect, near, far float32) Mat4 {
+	yScale := 1 / float32(math.Tan(float64(fovYRadians)/2))
+	xScale := yScale / aspect
+	return Mat4{
+		xScale, 0, 0, 0,
+		0, yScale, 0, 0,
+		0, 0, near / (near - far), 1,
+		0, 0, near * far / (far - near), 0,
+	}
+}
+
+// PerspectiveFovReverseZRH is like PerspectiveFovRH but maps depth in reverse,
+// the near plane to 1 and the far plane to 0.
+func PerspectiveFovReverseZRH(fovYRadians, aspect, near, far float32) Mat4 {
+	yScale := 1 / float32(math.Tan(float64(fovYRadians)/2))
+	xScale := yScale / aspect
+	return Mat4{
+		xScale, 0, 0, 0,
+		0, yScale, 0, 0,
+		0, 0, near / (far - near), -1,
+		0, 0, near * far / (far - near), 0,
+	}
+}
+
+// PerspectiveFovInfiniteLH is like PerspectiveFovLH with the far plane moved
+// to infinity. Depth is mapped from near to infinity onto the range 0 to 1.
+func PerspectiveFovInfiniteLH(fovYRadians, aspect, near float32) Mat4 {
+	yScale := 1 / float32(math.Tan(float64(fovYRadians)/2))
+	xScale := yScale / aspect
+	return Mat4{
+		xScale, 0, 0, 0,
+		0, yScale, 0, 0,
+		0, 0, 1, 1,
+		0, 0, -near, 0,
+	}
+}
+
+// PerspectiveFovInfiniteRH is like PerspectiveFovRH with the far plane moved
+// to infinity. Depth is mapped from near to infinity onto the range 0 to 1.
+func PerspectiveFovInfiniteRH(fovYRadians, aspect, near float32) Mat4 {
+	yScale := 1 / float32(math.Tan(float64(fovYRadians)/2))
+	xScale := yScale / aspect
+	return Mat4{
+		xScale, 0, 0, 0,
+		0, yScale, 0, 0,
+		0, 0, -1, -1,
+		0, 0, -near, 0,
+	}
+}
+
+// PerspectiveFovInfiniteReverseZLH combines PerspectiveFovReverseZLH and
+// PerspectiveFovInfiniteLH. Depth is mapped from near to infinity onto the
+// range 1 to 0.
+func PerspectiveFovInfiniteReverseZLH(fovYRadians, aspect, near float32) Mat4 {
+	yScale := 1 / float32(math.Tan(float64(fovYRadians)/2))
+	xScale := yScale / aspect
+	return Mat4{
+		xScale, 0, 0, 0,
+		0, yScale, 0, 0,
+		0, 0, 0, 1,
+		0, 0, near, 0,
+	}
+}
+
+// PerspectiveFovInfiniteReverseZRH combines PerspectiveFovReverseZRH and
+// PerspectiveFovInfiniteRH. Depth is mapped from near to infinity onto the
+// range 1 to 0.
+func PerspectiveFovInfiniteReverseZRH(fovYRadians, aspect, near float32) Mat4 {
+	yScale := 1 / float32(math.Tan(float64(fovYRadians)/2))
+	xScale := yScale / aspect
+	return Mat4{
+		xScale, 0, 0, 0,
+		0, yScale, 0, 0,
+		0, 0, 0, -1,
+		0, 0, near, 0,
+	}
+}
+
+// LinearizeDepth converts a depth value in the range 0 to 1, as produced by
+// PerspectiveFovLH and the other standard perspective projections, back to
+// the distance from the camera along the view direction.
+func LinearizeDepth(depth, near, far float32) float32 {
+	return near * far / (far - depth*(far-near))
+}
+
+// LinearizeReverseZDepth converts a depth value in the range 1 to 0, as
+// produced by PerspectiveFovReverseZLH or PerspectiveFovReverseZRH, back to
+// the distance from the camera along the view direction.
+func LinearizeReverseZDepth(depth, near, far float32) float32 {
+	return near * far / (near + depth*(far-near))
+}
+
+// LinearizeInfiniteDepth converts a depth value in the range 0 to 1, as
+// produced by PerspectiveFovInfiniteLH or PerspectiveFovInfiniteRH, back to
+// the distance from the camera along the view direction. A depth of 1 is
+// infinitely far away.
+func LinearizeInfiniteDepth(depth, near float32) float32 {
+	return near / (1 - depth)
+}
+
+// LinearizeInfiniteReverseZDepth converts a depth value in the range 1 to 0,
+// as produced by PerspectiveFovInfiniteReverseZLH or
+// PerspectiveFovInfiniteReverseZRH, back to the distance from the camera along
+// the view direction. A depth of 0 is infinitely far away.
+func LinearizeInfiniteReverseZDepth(depth, near float32) float32 {
+	return near / depth
+}
+
+// LookAtLH returns a left-handed view matrix like D3DXMatrixLookAtLH. The
+// camera at position pos looks at target along the positive z-axis. This is
+// the same as LookAt.
+func LookAtLH(pos, target, up Vec3) Mat4 {
+	return lookAlong(pos, target.Sub(pos), up)
+}
+
+// LookAtRH returns a right-handed view matrix like D3DXMatrixLookAtRH. The
+// camera at position pos looks at target along the negative z-axis.
+func LookAtRH(pos, target, up Vec3) Mat4 {
+	return lookAlong(pos, pos.Sub(target), up)
+}
+
+// LookAtOrbitLH returns a left-handed view matrix for a camera that orbits
+// around target at the given distance. The camera is at position
+// target.Add(Vec3FromSpherical(distance, azimuth, elevation)) and looks at
+// target along the positive z-axis. Its up vector is the positive y-axis,
+// tilted away from the camera as it rises, so the view stays defined when
+// looking straight down or up.
+func LookAtOrbitLH(target Vec3, distance, azimuth, elevation float32) Mat4 {
+	dir, up := orbitAxes(azimuth, elevation)
+	return lookAlong(target.Add(dir.MulScalar(distance)), dir.Negate(), up)
+}
+
+// LookAtOrbitRH is the right-handed version of LookAtOrbitLH. The camera looks
+// at target along the negative z-axis.
+func LookAtOrbitRH(target Vec3, distance, azimuth, elevation float32) Mat4 {
+	dir, up := orbitAxes(azimuth, elevation)
+	return lookAlong(target.Add(dir.MulScalar(distance)), dir, up)
+}
+
+// orbitAxes returns the direction from the target to an orbiting camera and
+// the camera's up vector, which is perpendicular to it.
+func orbitAxes(azimuth, elevation float32) (dir, up Vec3) {
+	return Vec3FromSpherical(1, azimuth, elevation),
+		Vec3FromSpherical(1, azimuth, elevation+0.25)
+}
+
+// lookAlong returns the view matrix for a camera at pos whose z-axis points in
+// direction dir.
+func lookAlong(pos, dir, up Vec3) Mat4 {
+	z := dir.Normalized()
+	x := up.Cross(z).Normalized()
+	y := z.Cross(x)
+	return Mat4{
+		x[0], y[0], z[0], 0,
+		x[1], y[1], z[1], 0,
+		x[2], y[2], z[2], 0,
+		-x.Dot(pos), -y.Dot(pos), -z.Dot(pos), 1,
+	}
+}
@@ -0,0 +1,294 @@
+package d3dmath
+
+import (
+	"math"
+	"testing"
+)
+
+func project(m Mat4, p Vec3) Vec3 {
+	return p.Homogeneous().MulMat(m).ByW()
+}
+
+func checkProjection(t *testing.T, m Mat4, p Vec3, want ...float32) {
+	t.Helper()
+	have := project(m, p)
+	checkFloatsNear(t, have[:], want...)
+}
+
+func TestPerspectiveFovLH(t *testing.T) {
+	m := PerspectiveFovLH(math.Pi/2, 2, 1, 3)
+	checkFloatsNear(t, m[:],
+		0.5, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, 1.5, 1,
+		0, 0, -1.5, 0,
+	)
+	p := Perspective(math.Pi/2, 2, 1, 3)
+	checkFloats(t, m[:], p[:]...)
+	checkProjection(t, m, Vec3{2, 1, 1}, 1, 1, 0)
+	checkProjection(t, m, Vec3{-6, -3, 3}, -1, -1, 1)
+}
+
+func TestPerspectiveFovRH(t *testing.T) {
+	m := PerspectiveFovRH(math.Pi/2, 2, 1, 3)
+	checkFloatsNear(t, m[:],
+		0.5, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, -1.5, -1,
+		0, 0, -1.5, 0,
+	)
+	checkProjection(t, m, Vec3{2, 1, -1}, 1, 1, 0)
+	checkProjection(t, m, Vec3{-6, -3, -3}, -1, -1, 1)
+}
+
+func TestPerspectiveLH(t *testing.T) {
+	m := PerspectiveLH(4, 2, 1, 3)
+	checkFloatsNear(t, m[:],
+		0.5, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, 1.5, 1,
+		0, 0, -1.5, 0,
+	)
+	checkProjection(t, m, Vec3{2, 1, 1}, 1, 1, 0)
+	checkProjection(t, m, Vec3{-6, -3, 3}, -1, -1, 1)
+}
+
+func TestPerspectiveRH(t *testing.T) {
+	m := PerspectiveRH(4, 2, 1, 3)
+	checkFloatsNear(t, m[:],
+		0.5, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, -1.5, -1,
+		0, 0, -1.5, 0,
+	)
+	checkProjection(t, m, Vec3{2, 1, -1}, 1, 1, 0)
+	checkProjection(t, m, Vec3{-6, -3, -3}, -1, -1, 1)
+}
+
+func TestPerspectiveOffCenterLH(t *testing.T) {
+	m := PerspectiveOffCenterLH(-1, 3, -2, 0, 1, 3)
+	checkFloatsNear(t, m[:],
+		0.5, 0, 0, 0,
+		0, 1, 0, 0,
+		-0.5, 1, 1.5, 1,
+		0, 0, -1.5, 0,
+	)
+	checkProjection(t, m, Vec3{3, 0, 1}, 1, 1, 0)
+	checkProjection(t, m, Vec3{-3, -6, 3}, -1, -1, 1)
+}
+
+func TestPerspectiveOffCenterRH(t *testing.T) {
+	m := PerspectiveOffCenterRH(-1, 3, -2, 0, 1, 3)
+	checkFloatsNear(t, m[:],
+		0.5, 0, 0, 0,
+		0, 1, 0, 0,
+		0.5, -1, -1.5, -1,
+		0, 0, -1.5, 0,
+	)
+	checkProjection(t, m, Vec3{3, 0, -1}, 1, 1, 0)
+	checkProjection(t, m, Vec3{-3, -6, -3}, -1, -1, 1)
+}
+
+func TestOrthoLH(t *testing.T) {
+	m := OrthoLH(4, 2, 1, 3)
+	checkFloatsNear(t, m[:],
+		0.5, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, 0.5, 0,
+		0, 0, -0.5, 1,
+	)
+	checkProjection(t, m, Vec3{2, -1, 1}, 1, -1, 0)
+	checkProjection(t, m, Vec3{-2, 1, 3}, -1, 1, 1)
+}
+
+func TestOrthoRH(t *testing.T) {
+	m := OrthoRH(4, 2, 1, 3)
+	checkFloatsNear(t, m[:],
+		0.5, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, -0.5, 0,
+		0, 0, -0.5, 1,
+	)
+	checkProjection(t, m, Vec3{2, -1, -1}, 1, -1, 0)
+	checkProjection(t, m, Vec3{-2, 1, -3}, -1, 1, 1)
+}
+
+func TestOrthoOffCenterLH(t *testing.T) {
+	m := OrthoOffCenterLH(-1, 3, -2, 0, 1, 3)
+	checkFloatsNear(t, m[:],
+		0.5, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, 0.5, 0,
+		-0.5, 1, -0.5, 1,
+	)
+	checkProjection(t, m, Vec3{-1, -2, 1}, -1, -1, 0)
+	checkProjection(t, m, Vec3{3, 0, 3}, 1, 1, 1)
+}
+
+func TestOrthoOffCenterRH(t *testing.T) {
+	m := OrthoOffCenterRH(-1, 3, -2, 0, 1, 3)
+	checkFloatsNear(t, m[:],
+		0.5, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, -0.5, 0,
+		-0.5, 1, -0.5, 1,
+	)
+	checkProjection(t, m, Vec3{-1, -2, -1}, -1, -1, 0)
+	checkProjection(t, m, Vec3{3, 0, -3}, 1, 1, 1)
+}
+
+func TestLookAtLH(t *testing.T) {
+	pos := Vec3{1, 2, 3}
+	target := Vec3{1, 2, 7}
+	up := Vec3{0, 1, 0}
+	m := LookAtLH(pos, target, up)
+	checkFloats(t, m[:],
+		1, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, 1, 0,
+		-1, -2, -3, 1,
+	)
+	l := LookAt(pos, target, up)
+	checkFloats(t, m[:], l[:]...)
+	m = LookAtLH(Vec3{1, 2, 3}, Vec3{4, 6, 3}, Vec3{0, 0, 1})
+	checkProjection(t, m, Vec3{4, 6, 3}, 0, 0, 5)
+	checkProjection(t, m, Vec3{1, 2, 5}, 0, 2, 0)
+}
+
+func TestLookAtRH(t *testing.T) {
+	pos := Vec3{1, 2, 3}
+	target := Vec3{1, 2, -1}
+	up := Vec3{0, 1, 0}
+	m := LookAtRH(pos, target, up)
+	checkFloats(t, m[:],
+		1, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, 1, 0,
+		-1, -2, -3, 1,
+	)
+	m = LookAtRH(Vec3{1, 2, 3}, Vec3{4, 6, 3}, Vec3{0, 0, 1})
+	checkProjection(t, m, Vec3{4, 6, 3}, 0, 0, -5)
+	checkProjection(t, m, Vec3{1, 2, 5}, 0, 2, 0)
+}
+
+func TestLookAtOrbit(t *testing.T) {
+	target := Vec3{1, 2, 3}
+	pos := target.Add(Vec3FromSpherical(5, 0.1, 0.2))
+	m := LookAtOrbitLH(target, 5, 0.1, 0.2)
+	want := LookAtLH(pos, target, Vec3{0, 1, 0})
+	checkFloatsNear(t, m[:], want[:]...)
+	m = LookAtOrbitRH(target, 5, 0.1, 0.2)
+	want = LookAtRH(pos, target, Vec3{0, 1, 0})
+	checkFloatsNear(t, m[:], want[:]...)
+
+	// Looking straight down from above, the camera's up vector points away
+	// from it along the azimuth, here the negative x-axis.
+	m = LookAtOrbitLH(target, 5, 0.25, 0.25)
+	checkProjection(t, m, target, 0, 0, 5)
+	checkProjection(t, m, Vec3{0, 2, 3}, 0, 1, 5)
+	m = LookAtOrbitRH(target, 5, 0.25, 0.25)
+	checkProjection(t, m, target, 0, 0, -5)
+	checkProjection(t, m, Vec3{0, 2, 3}, 0, 1, -5)
+}
+
+func TestReverseZAndInfinitePerspective(t *testing.T) {
+	const near, far = 0.5, 1000
+	fov := float32(math.Pi / 3)
+	tests := []struct {
+		name      string
+		m         Mat4
+		sign      float32
+		linearize func(depth float32) float32
+		nearDepth float32
+		farDepth  float32
+	}{
+		{
+			name:      "LH",
+			m:         PerspectiveFovLH(fov, 1.5, near, far),
+			sign:      1,
+			linearize: func(d float32) float32 { return LinearizeDepth(d, near, far) },
+			nearDepth: 0,
+			farDepth:  1,
+		},
+		{
+			name:      "RH",
+			m:         PerspectiveFovRH(fov, 1.5, near, far),
+			sign:      -1,
+			linearize: func(d float32) float32 { return LinearizeDepth(d, near, far) },
+			nearDepth: 0,
+			farDepth:  1,
+		},
+		{
+			name:      "reverse LH",
+			m:         PerspectiveFovReverseZLH(fov, 1.5, near, far),
+			sign:      1,
+			linearize: func(d float32) float32 { return LinearizeReverseZDepth(d, near, far) },
+			nearDepth: 1,
+			farDepth:  0,
+		},
+		{
+			name:      "reverse RH",
+			m:         PerspectiveFovReverseZRH(fov, 1.5, near, far),
+			sign:      -1,
+			linearize: func(d float32) float32 { return LinearizeReverseZDepth(d, near, far) },
+			nearDepth: 1,
+			farDepth:  0,
+		},
+		{
+			name:      "infinite LH",
+			m:         PerspectiveFovInfiniteLH(fov, 1.5, near),
+			sign:      1,
+			linearize: func(d float32) float32 { return LinearizeInfiniteDepth(d, near) },
+			nearDepth: 0,
+			farDepth:  1 - near/far,
+		},
+		{
+			name:      "infinite RH",
+			m:         PerspectiveFovInfiniteRH(fov, 1.5, near),
+			sign:      -1,
+			linearize: func(d float32) float32 { return LinearizeInfiniteDepth(d, near) },
+			nearDepth: 0,
+			farDepth:  1 - near/far,
+		},
+		{
+			name:      "infinite reverse LH",
+			m:         PerspectiveFovInfiniteReverseZLH(fov, 1.5, near),
+			sign:      1,
+			linearize: func(d float32) float32 { return LinearizeInfiniteReverseZDepth(d, near) },
+			nearDepth: 1,
+			farDepth:  near / far,
+		},
+		{
+			name:      "infinite reverse RH",
+			m:         PerspectiveFovInfiniteReverseZRH(fov, 1.5, near),
+			sign:      -1,
+			linearize: func(d float32) float32 { return LinearizeInfiniteReverseZDepth(d, near) },
+			nearDepth: 1,
+			farDepth:  near / far,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := project(test.m, Vec3{0, 0, test.sign * near})
+			checkFloatsNear(t, p[2:], test.nearDepth)
+			p = project(test.m, Vec3{0, 0, test.sign * far})
+			checkFloatsNear(t, p[2:], test.farDepth)
+			for _, dist := range []float32{near, 2, 10, 100} {
+				p := project(test.m, Vec3{1, 2, test.sign * dist})
+				have := test.linearize(p[2])
+				if math.Abs(float64(have-dist)) > 1e-4*float64(dist) {
+					t.Errorf("distance %v was linearized to %v", dist, have)
+				}
+			}
+		})
+	}
+}
+
+func TestReverseZMatchesStandardPerspectiveInXY(t *testing.T) {
+	p := Vec3{1, 2, 5}
+	standard := project(PerspectiveFovLH(1, 1.5, 0.5, 100), p)
+	reverse := project(PerspectiveFovReverseZLH(1, 1.5, 0.5, 100), p)
+	checkFloatsNear(t, reverse[:2], standard[:2]...)
+	infinite := project(PerspectiveFovInfiniteReverseZLH(1, 1.5, 0.5), p)
+	checkFloatsNear(t, infinite[:2], standard[:2]...)
+}
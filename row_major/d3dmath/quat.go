@@ -0,0 +1,345 @@
+package d3dmath
+
+import (
+	"fmt"
+	"math"
+)
+
+// Quat is a quaternion with elements x, y, z, w where w is the real part. Unit
+// quaternions represent rotations, like D3DXQUATERNION.
+//
+// Quaternions are multiplied in the same order as the matrices in this
+// package, q.Mul(r) is the rotation q followed by the rotation r, so
+// q.Mul(r).ToMat4() equals q.ToMat4().Mul(r.ToMat4()).
+type Quat [4]float32
+
+// IdentityQuat returns the quaternion that represents no rotation.
+func IdentityQuat() Quat {
+	return Quat{0, 0, 0, 1}
+}
+
+// QuatLeftHandAbout returns a quaternion that rotates about the given vector v,
+// applying the left-handed rule, by the given number of turns. 1 turn is 2*Pi.
+// It represents the same rotation as RotateLeftHandAbout.
+func QuatLeftHandAbout(v Vec3, turns float32) Quat {
+	sqLen := v.SquareNorm()
+	if sqLen == 0 {
+		return IdentityQuat()
+	}
+	if sqLen < 0.99999 || sqLen > 1.00001 {
+		v = v.Normalized()
+	}
+	s, c := math.Sincos(turnsToRadians(turns) / 2)
+	sin, cos := float32(s), float32(c)
+	return Quat{v[0] * sin, v[1] * sin, v[2] * sin, cos}
+}
+
+// QuatRightHandAbout returns a quaternion that rotates about the given vector
+// v, applying the right-handed rule, by the given number of turns. 1 turn is
+// 2*Pi. It represents the same rotation as RotateRightHandAbout.
+func QuatRightHandAbout(v Vec3, turns float32) Quat {
+	return QuatLeftHandAbout(v, -turns)
+}
+
+// QuatLeftHandX returns a quaternion that rotates about the x-axis, applying
+// the left-handed rule, by the given number of turns. 1 turn is 2*Pi.
+func QuatLeftHandX(turns float32) Quat {
+	return QuatLeftHandAbout(Vec3{1, 0, 0}, turns)
+}
+
+// QuatRightHandX returns a quaternion that rotates about the x-axis, applying
+// the right-handed rule, by the given number of turns. 1 turn is 2*Pi.
+func QuatRightHandX(turns float32) Quat {
+	return QuatLeftHandX(-turns)
+}
+
+// QuatLeftHandY returns a quaternion that rotates about the y-axis, applying
+// the left-handed rule, by the given number of turns. 1 turn is 2*Pi.
+func QuatLeftHandY(turns float32) Quat {
+	return QuatLeftHandAbout(Vec3{0, 1, 0}, turns)
+}
+
+// QuatRightHandY returns a quaternion that rotates about the y-axis, applying
+// the right-handed rule, by the given number of turns. 1 turn is 2*Pi.
+func QuatRightHandY(turns float32) Quat {
+	return QuatLeftHandY(-turns)
+}
+
+// QuatLeftHandZ returns a quaternion that rotates about the z-axis, applying
+// the left-handed rule, by the given number of turns. 1 turn is 2*Pi.
+func QuatLeftHandZ(turns float32) Quat {
+	return QuatLeftHandAbout(Vec3{0, 0, 1}, turns)
+}
+
+// QuatRightHandZ returns a quaternion that rotates about the z-axis, applying
+// the right-handed rule, by the given number of turns. 1 turn is 2*Pi.
+func QuatRightHandZ(turns float32) Quat {
+	return QuatLeftHandZ(-turns)
+}
+
+// QuatLeftHandYawPitchRoll returns a quaternion that first rotates by roll
+// about the z-axis, then by pitch about the x-axis and then by yaw about the
+// y-axis, all applying the left-handed rule and given in turns. This is the
+// same as D3DXQuaternionRotationYawPitchRoll with angles in turns.
+func QuatLeftHandYawPitchRoll(yaw, pitch, roll float32) Quat {
+	return QuatLeftHandZ(roll).Mul(QuatLeftHandX(pitch)).Mul(QuatLeftHandY(yaw))
+}
+
+// QuatRightHandYawPitchRoll returns a quaternion that first rotates by roll
+// about the z-axis, then by pitch about the x-axis and then by yaw about the
+// y-axis, all applying the right-handed rule and given in turns.
+func QuatRightHandYawPitchRoll(yaw, pitch, roll float32) Quat {
+	return QuatRightHandZ(roll).Mul(QuatRightHandX(pitch)).Mul(QuatRightHandY(yaw))
+}
+
+// RightHandAxisTurns returns the axis and the number of turns that q rotates
+// about, applying the right-handed rule. The axis has length 1 if q is a unit
+// quaternion. For the identity quaternion the axis is the zero vector.
+func (q Quat) RightHandAxisTurns() (axis Vec3, turns float32) {
+	v := Vec3{q[0], q[1], q[2]}
+	sin := v.Norm()
+	if sin == 0 {
+		return Vec3{}, 0
+	}
+	radians := 2 * math.Atan2(float64(sin), float64(q[3]))
+	return v.MulScalar(-1 / sin), float32(radians * RadToTurns)
+}
+
+// Negate returns a quaternion with all elements of q negated. It represents
+// the same rotation as q.
+func (q Quat) Negate() Quat {
+	return Quat{-q[0], -q[1], -q[2], -q[3]}
+}
+
+// Add returns the sum of q + r.
+func (q Quat) Add(r Quat) Quat {
+	return Quat{q[0] + r[0], q[1] + r[1], q[2] + r[2], q[3] + r[3]}
+}
+
+// Sub returns the difference of q - r.
+func (q Quat) Sub(r Quat) Quat {
+	return Quat{q[0] - r[0], q[1] - r[1], q[2] - r[2], q[3] - r[3]}
+}
+
+// MulScalar returns a quaternion with all elements of q scaled by s.
+func (q Quat) MulScalar(s float32) Quat {
+	return Quat{q[0] * s, q[1] * s, q[2] * s, q[3] * s}
+}
+
+// Dot returns the 4-dimensional dot-product of q and r.
+func (q Quat) Dot(r Quat) float32 {
+	return q[0]*r[0] + q[1]*r[1] + q[2]*r[2] + q[3]*r[3]
+}
+
+// Mul returns the rotation q followed by the rotation r, like
+// D3DXQuaternionMultiply. In Hamilton notation this is the product r * q.
+func (q Quat) Mul(r Quat) Quat {
+	return Quat{
+		r[3]*q[0] + r[0]*q[3] + r[1]*q[2] - r[2]*q[1],
+		r[3]*q[1] - r[0]*q[2] + r[1]*q[3] + r[2]*q[0],
+		r[3]*q[2] + r[0]*q[1] - r[1]*q[0] + r[2]*q[3],
+		r[3]*q[3] - r[0]*q[0] - r[1]*q[1] - r[2]*q[2],
+	}
+}
+
+// MulQuat returns the rotation of all given quaternions, applied in the order
+// in which they are given.
+func MulQuat(q0 Quat, q ...Quat) Quat {
+	if len(q) == 0 {
+		return q0
+	}
+	return q0.Mul(MulQuat(q[0], q[1:]...))
+}
+
+// Conjugate returns q with x, y and z negated. For unit quaternions this is the
+// inverse rotation.
+func (q Quat) Conjugate() Quat {
+	return Quat{-q[0], -q[1], -q[2], q[3]}
+}
+
+// Inverse returns the inverse of q, so that q.Mul(q.Inverse()) is the identity.
+// The inverse of the zero quaternion is the zero quaternion.
+func (q Quat) Inverse() Quat {
+	sqLen := q.SquareNorm()
+	if sqLen == 0 {
+		return Quat{}
+	}
+	return q.Conjugate().MulScalar(1 / sqLen)
+}
+
+// SquareNorm returns the square of the length of q.
+func (q Quat) SquareNorm() float32 {
+	return q.Dot(q)
+}
+
+// Norm returns the length of q.
+func (q Quat) Norm() float32 {
+	return float32(math.Sqrt(float64(q.SquareNorm())))
+}
+
+// Normalized returns a copy of q with elements normalized so the returned
+// quaternion has length 1, or the identity if q has length 0.
+func (q Quat) Normalized() Quat {
+	norm := q.Norm()
+	if norm == 0 {
+		return IdentityQuat()
+	}
+	return q.MulScalar(1 / norm)
+}
+
+// Ln returns the natural logarithm of the unit quaternion q, like
+// D3DXQuaternionLn.
+func (q Quat) Ln() Quat {
+	v := Vec3{q[0], q[1], q[2]}
+	sin := v.Norm()
+	if sin == 0 || q[3] >= 1 {
+		return Quat{q[0], q[1], q[2], 0}
+	}
+	f := float32(math.Atan2(float64(sin), float64(q[3]))) / sin
+	return Quat{f * q[0], f * q[1], f * q[2], 0}
+}
+
+// Exp returns the exponential of the pure quaternion q, like D3DXQuaternionExp.
+// The w element of q is ignored.
+func (q Quat) Exp() Quat {
+	theta := Vec3{q[0], q[1], q[2]}.Norm()
+	if theta == 0 {
+		return Quat{q[0], q[1], q[2], 1}
+	}
+	s, c := math.Sincos(float64(theta))
+	f := float32(s) / theta
+	return Quat{f * q[0], f * q[1], f * q[2], float32(c)}
+}
+
+// Rotate returns v rotated by the unit quaternion q. This is the same as
+// multiplying v with q.ToMat3().
+func (q Quat) Rotate(v Vec3) Vec3 {
+	u := Vec3{q[0], q[1], q[2]}
+	t := u.Cross(v).MulScalar(2)
+	return v.Add(t.MulScalar(q[3])).Add(u.Cross(t))
+}
+
+// Nlerp returns the normalized linear interpolation between q and r, taking the
+// shorter path. t is 0 for q and 1 for r. This is cheaper than Slerp but does
+// not rotate with constant angular velocity.
+func (q Quat) Nlerp(r Quat, t float32) Quat {
+	if q.Dot(r) < 0 {
+		r = r.Negate()
+	}
+	return q.Add(r.Sub(q).MulScalar(t)).Normalized()
+}
+
+// Slerp returns the spherical linear interpolation between the unit
+// quaternions q and r, taking the shorter path, like D3DXQuaternionSlerp. t is
+// 0 for q and 1 for r.
+func (q Quat) Slerp(r Quat, t float32) Quat {
+	cos := float64(q.Dot(r))
+	if cos < 0 {
+		r = r.Negate()
+		cos = -cos
+	}
+	if cos > 0.9995 {
+		// The angle is so small that sin would be close to 0. Linear
+		// interpolation is exact enough here.
+		return q.Nlerp(r, t)
+	}
+	theta := math.Acos(cos)
+	sin := math.Sin(theta)
+	a := float32(math.Sin((1-float64(t))*theta) / sin)
+	b := float32(math.Sin(float64(t)*theta) / sin)
+	return q.MulScalar(a).Add(r.MulScalar(b))
+}
+
+// Squad returns the spherical quadrangle interpolation from q1 to c with the
+// control points a and b, like D3DXQuaternionSquad. Use SquadSetup to compute
+// a, b and c.
+func Squad(q1, a, b, c Quat, t float32) Quat {
+	return q1.Slerp(c, t).Slerp(a.Slerp(b, t), 2*t*(1-t))
+}
+
+// SquadSetup returns the control points for Squad to smoothly interpolate
+// between q1 and q2, where q0 and q3 are the rotations before q1 and after q2,
+// like D3DXQuaternionSquadSetup.
+func SquadSetup(q0, q1, q2, q3 Quat) (a, b, c Quat) {
+	if q0.Dot(q1) < 0 {
+		q0 = q0.Negate()
+	}
+	c = q2
+	if q1.Dot(c) < 0 {
+		c = c.Negate()
+	}
+	if c.Dot(q3) < 0 {
+		q3 = q3.Negate()
+	}
+	a = squadControl(q0, q1, c)
+	b = squadControl(q1, c, q3)
+	return
+}
+
+func squadControl(prev, q, next Quat) Quat {
+	inv := q.Inverse()
+	sum := inv.Mul(prev).Ln().Add(inv.Mul(next).Ln())
+	return q.Mul(sum.MulScalar(-0.25).Exp())
+}
+
+// ToMat3 returns the 3 by 3 rotation matrix that represents the unit
+// quaternion q.
+func (q Quat) ToMat3() Mat3 {
+	x, y, z, w := q[0], q[1], q[2], q[3]
+	return Mat3{
+		1 - 2*(y*y+z*z), 2 * (x*y + z*w), 2 * (x*z - y*w),
+		2 * (x*y - z*w), 1 - 2*(x*x+z*z), 2 * (y*z + x*w),
+		2 * (x*z + y*w), 2 * (y*z - x*w), 1 - 2*(x*x+y*y),
+	}
+}
+
+// ToMat4 returns the homogeneous 4 by 4 rotation matrix that represents the
+// unit quaternion q, like D3DXMatrixRotationQuaternion.
+func (q Quat) ToMat4() Mat4 {
+	return q.ToMat3().Homogeneous()
+}
+
+// ToQuat returns the unit quaternion that represents the rotation matrix m,
+// like D3DXQuaternionRotationMatrix. m must be orthonormal.
+func (m Mat3) ToQuat() Quat {
+	return rotationToQuat(
+		m[0], m[1], m[2],
+		m[3], m[4], m[5],
+		m[6], m[7], m[8],
+	)
+}
+
+// ToQuat returns the unit quaternion that represents the rotation in the upper
+// left 3 by 3 part of m, like D3DXQuaternionRotationMatrix. That part must be
+// orthonormal.
+func (m Mat4) ToQuat() Quat {
+	return rotationToQuat(
+		m[0], m[1], m[2],
+		m[4], m[5], m[6],
+		m[8], m[9], m[10],
+	)
+}
+
+// rotationToQuat converts the rotation matrix with elements m<row><column> to
+// a quaternion.
+func rotationToQuat(m00, m01, m02, m10, m11, m12, m20, m21, m22 float32) Quat {
+	var q Quat
+	if trace := m00 + m11 + m22; trace > 0 {
+		s := 2 * float32(math.Sqrt(float64(trace+1)))
+		q = Quat{(m12 - m21) / s, (m20 - m02) / s, (m01 - m10) / s, s / 4}
+	} else if m00 > m11 && m00 > m22 {
+		s := 2 * float32(math.Sqrt(float64(1+m00-m11-m22)))
+		q = Quat{s / 4, (m01 + m10) / s, (m02 + m20) / s, (m12 - m21) / s}
+	} else if m11 > m22 {
+		s := 2 * float32(math.Sqrt(float64(1+m11-m00-m22)))
+		q = Quat{(m01 + m10) / s, s / 4, (m12 + m21) / s, (m20 - m02) / s}
+	} else {
+		s := 2 * float32(math.Sqrt(float64(1+m22-m00-m11)))
+		q = Quat{(m02 + m20) / s, (m12 + m21) / s, s / 4, (m01 - m10) / s}
+	}
+	return q.Normalized()
+}
+
+func (q Quat) String() string {
+	return fmt.Sprintf("(%.2f %.2f %.2f %.2f)", q[0], q[1], q[2], q[3])
+}
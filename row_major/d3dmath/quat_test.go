@@ -0,0 +1,194 @@
+package d3dmath
+
+import "testing"
+
+func TestIdentityQuat(t *testing.T) {
+	q := IdentityQuat()
+	checkFloats(t, q[:], 0, 0, 0, 1)
+	m := q.ToMat4()
+	id := Identity4()
+	checkFloats(t, m[:], id[:]...)
+}
+
+func TestQuatRotation(t *testing.T) {
+	// We rotate vector v around different axes, the same way as in
+	// TestRotation.
+	v := Vec3{2, 3, 4}
+	check := func(q Quat, x, y, z float32) {
+		have := q.Rotate(v)
+		checkFloatsNear(t, have[:], x, y, z)
+		have = v.MulMat(q.ToMat3())
+		checkFloatsNear(t, have[:], x, y, z)
+	}
+
+	x := Vec3{1, 0, 0}
+	y := Vec3{0, 1, 0}
+	z := Vec3{0, 0, 1}
+
+	check(QuatRightHandX(0.25), 2, 4, -3)
+	check(QuatRightHandAbout(x, 0.25), 2, 4, -3)
+	check(QuatLeftHandX(0.25), 2, -4, 3)
+	check(QuatLeftHandAbout(x, 0.25), 2, -4, 3)
+	check(QuatRightHandY(0.25), -4, 3, 2)
+	check(QuatRightHandAbout(y, 0.25), -4, 3, 2)
+	check(QuatLeftHandY(0.25), 4, 3, -2)
+	check(QuatLeftHandAbout(y, 0.25), 4, 3, -2)
+	check(QuatRightHandZ(0.25), 3, -2, 4)
+	check(QuatRightHandAbout(z, 0.25), 3, -2, 4)
+	check(QuatLeftHandZ(0.25), -3, 2, 4)
+	check(QuatLeftHandAbout(z, 0.25), -3, 2, 4)
+}
+
+func TestQuatMatchesRotationMatrix(t *testing.T) {
+	axis := Vec3{3, -4, 5}
+	for _, turns := range []float32{0, 0.1, 0.25, 0.5, 0.8} {
+		q := QuatRightHandAbout(axis, turns).ToMat4()
+		m := RotateRightHandAbout(axis, turns)
+		checkFloatsNear(t, q[:], m[:]...)
+		q = QuatLeftHandAbout(axis, turns).ToMat4()
+		m = RotateLeftHandAbout(axis, turns)
+		checkFloatsNear(t, q[:], m[:]...)
+	}
+}
+
+func TestQuatZeroAxisIsIdentity(t *testing.T) {
+	q := QuatRightHandAbout(Vec3{}, 0.3)
+	checkFloats(t, q[:], 0, 0, 0, 1)
+}
+
+func TestQuatMulMatchesMatrixOrder(t *testing.T) {
+	a := QuatRightHandX(0.1)
+	b := QuatRightHandAbout(Vec3{1, 2, 3}, 0.3)
+	c := QuatLeftHandZ(0.2)
+	have := MulQuat(a, b, c).ToMat4()
+	want := Mul4(a.ToMat4(), b.ToMat4(), c.ToMat4())
+	checkFloatsNear(t, have[:], want[:]...)
+}
+
+func TestQuatYawPitchRoll(t *testing.T) {
+	q := QuatLeftHandYawPitchRoll(0.1, 0.2, 0.3).ToMat4()
+	m := Mul4(RotateLeftHandZ(0.3), RotateLeftHandX(0.2), RotateLeftHandY(0.1))
+	checkFloatsNear(t, q[:], m[:]...)
+
+	q = QuatRightHandYawPitchRoll(0.1, 0.2, 0.3).ToMat4()
+	m = Mul4(RotateRightHandZ(0.3), RotateRightHandX(0.2), RotateRightHandY(0.1))
+	checkFloatsNear(t, q[:], m[:]...)
+}
+
+func TestQuatConjugate(t *testing.T) {
+	q := Quat{1, 2, 3, 4}.Conjugate()
+	checkFloats(t, q[:], -1, -2, -3, 4)
+}
+
+func TestQuatInverse(t *testing.T) {
+	q := Quat{1, 2, 3, 4}
+	id := q.Mul(q.Inverse())
+	checkFloatsNear(t, id[:], 0, 0, 0, 1)
+	id = q.Inverse().Mul(q)
+	checkFloatsNear(t, id[:], 0, 0, 0, 1)
+	zero := Quat{}.Inverse()
+	checkFloats(t, zero[:], 0, 0, 0, 0)
+}
+
+func TestQuatNormalized(t *testing.T) {
+	q := Quat{1, 2, 2, 4}.Normalized()
+	checkFloats(t, q[:], 0.2, 0.4, 0.4, 0.8)
+	q = Quat{}.Normalized()
+	checkFloats(t, q[:], 0, 0, 0, 1)
+}
+
+func TestQuatRightHandAxisTurns(t *testing.T) {
+	axis, turns := QuatRightHandAbout(Vec3{0, 3, 4}, 0.3).RightHandAxisTurns()
+	checkFloatsNear(t, axis[:], 0, 0.6, 0.8)
+	checkFloatsNear(t, []float32{turns}, 0.3)
+
+	axis, turns = QuatLeftHandAbout(Vec3{0, 3, 4}, 0.3).RightHandAxisTurns()
+	checkFloatsNear(t, axis[:], 0, -0.6, -0.8)
+	checkFloatsNear(t, []float32{turns}, 0.3)
+
+	axis, turns = IdentityQuat().RightHandAxisTurns()
+	checkFloats(t, axis[:], 0, 0, 0)
+	checkFloat(t, turns, 0)
+}
+
+func TestMatToQuat(t *testing.T) {
+	// Each of these rotations takes a different branch in the conversion.
+	for _, q := range []Quat{
+		QuatRightHandAbout(Vec3{1, 2, 3}, 0.1),
+		QuatRightHandX(0.45),
+		QuatRightHandY(0.45),
+		QuatRightHandZ(0.45),
+	} {
+		m := q.ToMat4()
+		have := m.ToQuat()
+		if have.Dot(q) < 0 {
+			have = have.Negate()
+		}
+		checkFloatsNear(t, have[:], q[:]...)
+		have = q.ToMat3().ToQuat()
+		if have.Dot(q) < 0 {
+			have = have.Negate()
+		}
+		checkFloatsNear(t, have[:], q[:]...)
+	}
+}
+
+func TestQuatLnExp(t *testing.T) {
+	q := QuatRightHandAbout(Vec3{1, 2, 3}, 0.2)
+	have := q.Ln().Exp()
+	checkFloatsNear(t, have[:], q[:]...)
+	id := IdentityQuat().Ln().Exp()
+	checkFloats(t, id[:], 0, 0, 0, 1)
+}
+
+func TestQuatSlerp(t *testing.T) {
+	a := QuatRightHandZ(0)
+	b := QuatRightHandZ(0.25)
+	q := a.Slerp(b, 0)
+	checkFloatsNear(t, q[:], a[:]...)
+	q = a.Slerp(b, 1)
+	checkFloatsNear(t, q[:], b[:]...)
+	q = a.Slerp(b, 0.5)
+	want := QuatRightHandZ(0.125)
+	checkFloatsNear(t, q[:], want[:]...)
+	// The shorter path is taken when the signs differ.
+	q = a.Slerp(b.Negate(), 0.5)
+	checkFloatsNear(t, q[:], want[:]...)
+	// Nearly identical rotations fall back to linear interpolation.
+	c := QuatRightHandZ(0.00001)
+	q = a.Slerp(c, 0.5)
+	want = QuatRightHandZ(0.000005)
+	checkFloatsNear(t, q[:], want[:]...)
+}
+
+func TestQuatNlerp(t *testing.T) {
+	a := QuatRightHandZ(0)
+	b := QuatRightHandZ(0.25)
+	q := a.Nlerp(b, 0.5)
+	want := QuatRightHandZ(0.125)
+	checkFloatsNear(t, q[:], want[:]...)
+	q = a.Nlerp(b.Negate(), 0.5)
+	checkFloatsNear(t, q[:], want[:]...)
+}
+
+func TestSquad(t *testing.T) {
+	q0 := QuatRightHandZ(0)
+	q1 := QuatRightHandZ(0.1)
+	q2 := QuatRightHandZ(0.2)
+	q3 := QuatRightHandZ(0.3)
+	a, b, c := SquadSetup(q0, q1, q2, q3)
+	q := Squad(q1, a, b, c, 0)
+	checkFloatsNear(t, q[:], q1[:]...)
+	q = Squad(q1, a, b, c, 1)
+	checkFloatsNear(t, q[:], q2[:]...)
+	// Rotations about a single axis with constant speed are interpolated
+	// linearly.
+	q = Squad(q1, a, b, c, 0.5)
+	want := QuatRightHandZ(0.15)
+	checkFloatsNear(t, q[:], want[:]...)
+}
+
+func TestQuatString(t *testing.T) {
+	q := Quat{1, 2, 3, 4}
+	checkString(t, q.String(), "(1.00 2.00 3.00 4.00)")
+}
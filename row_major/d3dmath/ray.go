@@ -0,0 +1,133 @@
+package d3dmath
+
+import "math"
+
+// Ray is a half-line that starts at Origin and extends infinitely in
+// Direction.
+type Ray struct {
+	Origin    Vec3
+	Direction Vec3
+}
+
+// At returns the point Origin + t * Direction on the ray.
+func (r Ray) At(t float32) Vec3 {
+	return r.Origin.Add(r.Direction.MulScalar(t))
+}
+
+// IntersectPlane returns the smallest t >= 0 for which r.At(t) lies on plane
+// p. hit is false if the ray is parallel to the plane or points away from it.
+func (r Ray) IntersectPlane(p Plane) (t float32, hit bool) {
+	denom := p.DotNormal(r.Direction)
+	if denom == 0 {
+		return 0, false
+	}
+	t = -p.DotCoord(r.Origin) / denom
+	if t < 0 {
+		return 0, false
+	}
+	return t, true
+}
+
+// IntersectSphere returns the smallest t >= 0 for which r.At(t) lies on or in
+// sphere s. If the ray starts inside the sphere, t is 0. hit is false if the
+// ray misses the sphere.
+func (r Ray) IntersectSphere(s Sphere) (t float32, hit bool) {
+	m := r.Origin.Sub(s.Center)
+	a := r.Direction.SquareNorm()
+	b := m.Dot(r.Direction)
+	c := m.SquareNorm() - s.Radius*s.Radius
+	if c <= 0 {
+		return 0, true
+	}
+	if b > 0 || a == 0 {
+		return 0, false
+	}
+	disc := b*b - a*c
+	if disc < 0 {
+		return 0, false
+	}
+	return (-b - float32(math.Sqrt(float64(disc)))) / a, true
+}
+
+// IntersectAABB returns the smallest t >= 0 for which r.At(t) lies on or in
+// box b, using the slab method. If the ray starts inside the box, t is 0. hit
+// is false if the ray misses the box.
+func (r Ray) IntersectAABB(b AABB) (t float32, hit bool) {
+	return intersectSlabs(r.Origin, r.Direction, b.Min, b.Max)
+}
+
+// IntersectOBB returns the smallest t >= 0 for which r.At(t) lies on or in
+// box b. If the ray starts inside the box, t is 0. hit is false if the ray
+// misses the box.
+func (r Ray) IntersectOBB(b OBB) (t float32, hit bool) {
+	// Transform the ray into the coordinate system of the box where it is
+	// axis-aligned and centered at the origin.
+	d := r.Origin.Sub(b.Center)
+	var origin, dir [3]float32
+	for i, axis := range b.Axes {
+		origin[i] = d.Dot(axis)
+		dir[i] = r.Direction.Dot(axis)
+	}
+	h := b.HalfSize
+	return intersectSlabs(origin, dir, [3]float32{-h[0], -h[1], -h[2]}, h)
+}
+
+func intersectSlabs(origin, dir, min, max [3]float32) (t float32, hit bool) {
+	tMin := float32(0)
+	tMax := float32(math.Inf(1))
+	for i := range origin {
+		if dir[i] == 0 {
+			if origin[i] < min[i] || origin[i] > max[i] {
+				return 0, false
+			}
+			continue
+		}
+		f := 1 / dir[i]
+		t1 := (min[i] - origin[i]) * f
+		t2 := (max[i] - origin[i]) * f
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		if t1 > tMin {
+			tMin = t1
+		}
+		if t2 < tMax {
+			tMax = t2
+		}
+		if tMin > tMax {
+			return 0, false
+		}
+	}
+	return tMin, true
+}
+
+// IntersectTriangle returns where the ray hits the triangle v0, v1, v2, like
+// D3DXIntersectTri. It uses the Möller-Trumbore algorithm and hits triangles
+// from both sides. The hit point is r.At(t) which is also
+// v0 + u * (v1 - v0) + v * (v2 - v0) with the barycentric coordinates u and v.
+// hit is false if the ray misses the triangle.
+func (r Ray) IntersectTriangle(v0, v1, v2 Vec3) (t, u, v float32, hit bool) {
+	e1 := v1.Sub(v0)
+	e2 := v2.Sub(v0)
+	p := r.Direction.Cross(e2)
+	det := e1.Dot(p)
+	if det == 0 {
+		return 0, 0, 0, false
+	}
+	f := 1 / det
+	s := r.Origin.Sub(v0)
+	u = f * s.Dot(p)
+	if u < 0 || u > 1 {
+		return 0, 0, 0, false
+	}
+	q := s.Cross(e1)
+	v = f * r.Direction.Dot(q)
+	if v < 0 || u+v > 1 {
+		return 0, 0, 0, false
+	}
+	t = f * e2.Dot(q)
+	if t < 0 {
+		return 0, 0, 0, false
+	}
+	return t, u, v, true
+}
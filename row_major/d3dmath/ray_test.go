@@ -0,0 +1,120 @@
+package d3dmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRayAt(t *testing.T) {
+	r := Ray{Origin: Vec3{1, 2, 3}, Direction: Vec3{0, 1, 0}}
+	p := r.At(2)
+	checkFloats(t, p[:], 1, 4, 3)
+}
+
+func checkHit(t *testing.T, hit bool, dist float32, wantHit bool, wantDist float32) {
+	t.Helper()
+	if hit != wantHit {
+		t.Errorf("hit is %v but want %v", hit, wantHit)
+	} else if hit {
+		checkFloatsNear(t, []float32{dist}, wantDist)
+	}
+}
+
+func TestRayIntersectPlane(t *testing.T) {
+	// The plane y = 2.
+	p := Plane{0, 1, 0, -2}
+	dist, hit := Ray{Vec3{1, 0, 1}, Vec3{0, 1, 0}}.IntersectPlane(p)
+	checkHit(t, hit, dist, true, 2)
+	dist, hit = Ray{Vec3{1, 0, 1}, Vec3{0, 2, 0}}.IntersectPlane(p)
+	checkHit(t, hit, dist, true, 1)
+	dist, hit = Ray{Vec3{1, 5, 1}, Vec3{0, -1, 0}}.IntersectPlane(p)
+	checkHit(t, hit, dist, true, 3)
+	// Pointing away from the plane.
+	dist, hit = Ray{Vec3{1, 0, 1}, Vec3{0, -1, 0}}.IntersectPlane(p)
+	checkHit(t, hit, dist, false, 0)
+	// Parallel to the plane.
+	dist, hit = Ray{Vec3{1, 0, 1}, Vec3{1, 0, 0}}.IntersectPlane(p)
+	checkHit(t, hit, dist, false, 0)
+}
+
+func TestRayIntersectSphere(t *testing.T) {
+	s := Sphere{Center: Vec3{0, 0, 5}, Radius: 2}
+	dist, hit := Ray{Vec3{0, 0, 0}, Vec3{0, 0, 1}}.IntersectSphere(s)
+	checkHit(t, hit, dist, true, 3)
+	dist, hit = Ray{Vec3{0, 0, 0}, Vec3{0, 0, 2}}.IntersectSphere(s)
+	checkHit(t, hit, dist, true, 1.5)
+	// Starting inside the sphere.
+	dist, hit = Ray{Vec3{0, 1, 5}, Vec3{0, 0, 1}}.IntersectSphere(s)
+	checkHit(t, hit, dist, true, 0)
+	// Pointing away from the sphere.
+	dist, hit = Ray{Vec3{0, 0, 0}, Vec3{0, 0, -1}}.IntersectSphere(s)
+	checkHit(t, hit, dist, false, 0)
+	// Passing the sphere.
+	dist, hit = Ray{Vec3{0, 3, 0}, Vec3{0, 0, 1}}.IntersectSphere(s)
+	checkHit(t, hit, dist, false, 0)
+}
+
+func TestRayIntersectAABB(t *testing.T) {
+	b := AABB{Min: Vec3{1, 2, 3}, Max: Vec3{2, 4, 6}}
+	dist, hit := Ray{Vec3{0, 3, 4}, Vec3{1, 0, 0}}.IntersectAABB(b)
+	checkHit(t, hit, dist, true, 1)
+	dist, hit = Ray{Vec3{5, 3, 4}, Vec3{-1, 0, 0}}.IntersectAABB(b)
+	checkHit(t, hit, dist, true, 3)
+	dist, hit = Ray{Vec3{0, 0, 0}, Vec3{1, 2, 3}}.IntersectAABB(b)
+	checkHit(t, hit, dist, true, 1)
+	// Starting inside the box.
+	dist, hit = Ray{Vec3{1.5, 3, 4}, Vec3{0, 1, 0}}.IntersectAABB(b)
+	checkHit(t, hit, dist, true, 0)
+	// Pointing away from the box.
+	dist, hit = Ray{Vec3{0, 3, 4}, Vec3{-1, 0, 0}}.IntersectAABB(b)
+	checkHit(t, hit, dist, false, 0)
+	// Parallel to and outside a slab.
+	dist, hit = Ray{Vec3{0, 5, 4}, Vec3{1, 0, 0}}.IntersectAABB(b)
+	checkHit(t, hit, dist, false, 0)
+	// Passing the box diagonally.
+	dist, hit = Ray{Vec3{0, 0, 0}, Vec3{1, 0, 1}}.IntersectAABB(b)
+	checkHit(t, hit, dist, false, 0)
+}
+
+func TestRayIntersectOBB(t *testing.T) {
+	b := AABB{Min: Vec3{-1, -1, -1}, Max: Vec3{1, 1, 1}}
+	// A cube of edge length 4, rotated by 1/8 turn about z, moved to x = 10.
+	o := OBBFromAABB(b, Mul4(Scale(2, 2, 2), RotateRightHandZ(0.125), Translate(10, 0, 0)))
+	checkFloatsNear(t, o.Center[:], 10, 0, 0)
+	checkFloatsNear(t, o.HalfSize[:], 2, 2, 2)
+	// The corner of the rotated cube points towards the ray.
+	dist, hit := Ray{Vec3{0, 0, 0}, Vec3{1, 0, 0}}.IntersectOBB(o)
+	checkHit(t, hit, dist, true, 10-2*math.Sqrt2)
+	dist, hit = Ray{Vec3{0, 3, 0}, Vec3{1, 0, 0}}.IntersectOBB(o)
+	checkHit(t, hit, dist, false, 0)
+	dist, hit = Ray{Vec3{10, 0, 0}, Vec3{1, 0, 0}}.IntersectOBB(o)
+	checkHit(t, hit, dist, true, 0)
+	if !o.ContainsPoint(Vec3{11, 1, 1}) {
+		t.Error("point should be in box")
+	}
+	if o.ContainsPoint(Vec3{12, 1.5, 0}) {
+		t.Error("point should not be in box")
+	}
+}
+
+func TestRayIntersectTriangle(t *testing.T) {
+	v0 := Vec3{0, 0, 5}
+	v1 := Vec3{4, 0, 5}
+	v2 := Vec3{0, 2, 5}
+	dist, u, v, hit := Ray{Vec3{1, 1, 0}, Vec3{0, 0, 1}}.IntersectTriangle(v0, v1, v2)
+	checkHit(t, hit, dist, true, 5)
+	checkFloatsNear(t, []float32{u, v}, 0.25, 0.5)
+	// Triangles are hit from both sides.
+	dist, u, v, hit = Ray{Vec3{1, 1, 10}, Vec3{0, 0, -2}}.IntersectTriangle(v0, v1, v2)
+	checkHit(t, hit, dist, true, 2.5)
+	checkFloatsNear(t, []float32{u, v}, 0.25, 0.5)
+	// Missing the triangle.
+	_, _, _, hit = Ray{Vec3{3, 1.5, 0}, Vec3{0, 0, 1}}.IntersectTriangle(v0, v1, v2)
+	checkHit(t, hit, 0, false, 0)
+	// Pointing away from the triangle.
+	_, _, _, hit = Ray{Vec3{1, 1, 0}, Vec3{0, 0, -1}}.IntersectTriangle(v0, v1, v2)
+	checkHit(t, hit, 0, false, 0)
+	// Parallel to the triangle.
+	_, _, _, hit = Ray{Vec3{1, 1, 5}, Vec3{1, 0, 0}}.IntersectTriangle(v0, v1, v2)
+	checkHit(t, hit, 0, false, 0)
+}
@@ -0,0 +1,131 @@
+package d3dmath
+
+import (
+	"testing"
+
+	"github.com/gonutz/d3dmath/internal/simd"
+)
+
+// checkSIMD runs f once with and once without SIMD instructions and requires
+// the results to be at most 4 ULPs apart. On amd64 they are usually identical,
+// but the Go compiler may fuse multiplications and additions on some
+// platforms, which changes the last bits.
+func checkSIMD(t *testing.T, name string, f func() []float32) {
+	t.Helper()
+	enabled := simd.Enabled
+	defer func() { simd.Enabled = enabled }()
+	simd.Enabled = true
+	have := f()
+	simd.Enabled = false
+	want := f()
+	for i := range have {
+		if ULPDistance(have[i], want[i]) > 4 {
+			t.Errorf("%s: element %d is %v with SIMD but %v in Go", name, i, have[i], want[i])
+		}
+	}
+}
+
+func TestSIMDMatchesGo(t *testing.T) {
+	t.Log("using", simd.Implementation())
+	m := batchMatrix()
+	n := Mul4(RotateLeftHandAbout(Vec3{3, 2, 1}, 0.3), Translate(5, 6, 7))
+	v := batchPoints(9)
+	v4 := make([]Vec4, len(v))
+	for i := range v {
+		v4[i] = Vec4{v[i][0], v[i][1], v[i][2], float32(i%3) - 1}
+	}
+
+	checkSIMD(t, "Mul", func() []float32 {
+		p := m.Mul(n)
+		return p[:]
+	})
+	checkSIMD(t, "Mul4", func() []float32 {
+		p := Mul4(m, n, m)
+		return p[:]
+	})
+	checkSIMD(t, "TransformArray", func() []float32 {
+		out := make([]Vec4, len(v))
+		TransformArray(out, v, m)
+		return vec4Floats(out)
+	})
+	checkSIMD(t, "TransformCoordArray", func() []float32 {
+		out := make([]Vec3, len(v))
+		TransformCoordArray(out, v, m)
+		return vec3Floats(out)
+	})
+	checkSIMD(t, "TransformNormalArray", func() []float32 {
+		out := make([]Vec3, len(v))
+		TransformNormalArray(out, v, m)
+		return vec3Floats(out)
+	})
+	checkSIMD(t, "TransformVec4Array", func() []float32 {
+		out := make([]Vec4, len(v4))
+		TransformVec4Array(out, v4, m)
+		return vec4Floats(out)
+	})
+
+	buf := vec4Floats(v4)
+	checkSIMD(t, "TransformStrided", func() []float32 {
+		out := make([]float32, 4*len(v4))
+		TransformStrided(out, 4, buf, 4, len(v4), m)
+		return out
+	})
+	checkSIMD(t, "TransformCoordStrided", func() []float32 {
+		out := make([]float32, 4*len(v4))
+		TransformCoordStrided(out, 4, buf, 4, len(v4), m)
+		return out
+	})
+	checkSIMD(t, "TransformNormalStrided", func() []float32 {
+		out := make([]float32, 4*len(v4))
+		TransformNormalStrided(out, 4, buf, 4, len(v4), m)
+		return out
+	})
+}
+
+func TestStridedRejectsNegativeStrides(t *testing.T) {
+	enabled := simd.Enabled
+	defer func() { simd.Enabled = enabled }()
+	transforms := map[string]func(out []float32, outStride int, v []float32, vStride, n int, m Mat4){
+		"TransformStrided":       TransformStrided,
+		"TransformCoordStrided":  TransformCoordStrided,
+		"TransformNormalStrided": TransformNormalStrided,
+	}
+	for _, on := range []bool{true, false} {
+		simd.Enabled = on
+		for name, f := range transforms {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("%s with SIMD %v did not panic", name, on)
+					}
+				}()
+				buf := make([]float32, 16)
+				f(buf[8:], -1, buf[8:], 0, 3, Identity4())
+			}()
+		}
+	}
+}
+
+// benchmarkSIMD runs f as a sub-benchmark once with and once without SIMD
+// instructions.
+func benchmarkSIMD(b *testing.B, f func(b *testing.B)) {
+	enabled := simd.Enabled
+	defer func() { simd.Enabled = enabled }()
+	simd.Enabled = false
+	b.Run("go", f)
+	if enabled {
+		simd.Enabled = true
+		b.Run(simd.Implementation(), f)
+	}
+}
+
+var mat4Sink Mat4
+
+func BenchmarkMat4Mul(b *testing.B) {
+	m, n := batchMatrix(), RotateLeftHandX(0.1)
+	benchmarkSIMD(b, func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			mat4Sink = m.Mul(n)
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package d3dmath
+
+// Sphere is the set of all points with a distance of at most Radius to Center.
+type Sphere struct {
+	Center Vec3
+	Radius float32
+}
+
+// ContainsPoint returns true if p lies on or in s.
+func (s Sphere) ContainsPoint(p Vec3) bool {
+	return p.Sub(s.Center).SquareNorm() <= s.Radius*s.Radius
+}
+
+// IntersectsSphere returns true if s and t overlap or touch.
+func (s Sphere) IntersectsSphere(t Sphere) bool {
+	r := s.Radius + t.Radius
+	return s.Center.Sub(t.Center).SquareNorm() <= r*r
+}
+
+// IntersectsAABB returns true if s and b overlap or touch.
+func (s Sphere) IntersectsAABB(b AABB) bool {
+	return s.ContainsPoint(b.ClosestPoint(s.Center))
+}
@@ -0,0 +1,26 @@
+package d3dmath
+
+import "testing"
+
+func TestSphereContainsPoint(t *testing.T) {
+	s := Sphere{Center: Vec3{1, 2, 3}, Radius: 2}
+	if !s.ContainsPoint(Vec3{1, 4, 3}) {
+		t.Error("point on the surface should be contained")
+	}
+	if s.ContainsPoint(Vec3{2.5, 3.5, 3}) {
+		t.Error("point outside should not be contained")
+	}
+}
+
+func TestSphereIntersectsSphere(t *testing.T) {
+	s := Sphere{Center: Vec3{0, 0, 0}, Radius: 2}
+	if !s.IntersectsSphere(Sphere{Center: Vec3{3, 0, 0}, Radius: 1}) {
+		t.Error("touching spheres should intersect")
+	}
+	if !s.IntersectsSphere(Sphere{Center: Vec3{0, 0, 0}, Radius: 1}) {
+		t.Error("contained sphere should intersect")
+	}
+	if s.IntersectsSphere(Sphere{Center: Vec3{3, 1, 0}, Radius: 1}) {
+		t.Error("separate spheres should not intersect")
+	}
+}
@@ -0,0 +1,83 @@
+package d3dmath
+
+// Transformation returns a matrix that, like D3DXMatrixTransformation, scales
+// by scaling along the axes given by scalingRotation about scalingCenter, then
+// rotates by rotation about rotationCenter and finally translates by
+// translation. Pass a zero vector or the identity quaternion for the parts you
+// do not need.
+//
+// The result is Msc^-1 * Msr^-1 * Ms * Msr * Msc * Mrc^-1 * Mr * Mrc * Mt in
+// row vector notation.
+func Transformation(
+	scalingCenter Vec3,
+	scalingRotation Quat,
+	scaling Vec3,
+	rotationCenter Vec3,
+	rotation Quat,
+	translation Vec3,
+) Mat4 {
+	return Mul4(
+		TranslateV(scalingCenter.Negate()),
+		scalingRotation.Inverse().ToMat4(),
+		ScaleV(scaling),
+		scalingRotation.ToMat4(),
+		TranslateV(scalingCenter.Sub(rotationCenter)),
+		rotation.ToMat4(),
+		TranslateV(rotationCenter.Add(translation)),
+	)
+}
+
+// AffineTransformation returns a matrix that, like
+// D3DXMatrixAffineTransformation, scales uniformly by scaling, then rotates by
+// rotation about rotationCenter and finally translates by translation.
+func AffineTransformation(
+	scaling float32,
+	rotationCenter Vec3,
+	rotation Quat,
+	translation Vec3,
+) Mat4 {
+	return Transformation(
+		Vec3{}, IdentityQuat(), Vec3{scaling, scaling, scaling},
+		rotationCenter, rotation, translation,
+	)
+}
+
+// Transformation2D returns a matrix that, like D3DXMatrixTransformation2D,
+// scales by scaling along the axes rotated by scalingRotation about
+// scalingCenter, then rotates by rotation about rotationCenter and finally
+// translates by translation. Rotations are given in turns and go from the
+// x-axis towards the y-axis, which is the same direction as RotateLeftHandZ.
+func Transformation2D(
+	scalingCenter Vec2,
+	scalingRotation float32,
+	scaling Vec2,
+	rotationCenter Vec2,
+	rotation float32,
+	translation Vec2,
+) Mat2x3 {
+	return Mul2x3(
+		Translate2DV(rotationCenter.Add(translation)),
+		RotateLeftHand2D(rotation),
+		Translate2DV(scalingCenter.Sub(rotationCenter)),
+		RotateLeftHand2D(scalingRotation),
+		Scale2DV(scaling),
+		RotateLeftHand2D(-scalingRotation),
+		Translate2DV(scalingCenter.Negate()),
+	)
+}
+
+// AffineTransformation2D returns a matrix that, like
+// D3DXMatrixAffineTransformation2D, scales uniformly by scaling, then rotates
+// by rotation turns about rotationCenter and finally translates by
+// translation.
+func AffineTransformation2D(
+	scaling float32,
+	rotationCenter Vec2,
+	rotation float32,
+	translation Vec2,
+) Mat2x3 {
+	return Transformation2D(
+		Vec2{}, 0, Vec2{scaling, scaling},
+		rotationCenter, rotation, translation,
+	)
+}
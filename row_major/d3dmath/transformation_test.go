@@ -0,0 +1,101 @@
+package d3dmath
+
+import "testing"
+
+func TestTransformation(t *testing.T) {
+	q := QuatLeftHandAbout(Vec3{1, 2, 3}, 0.3)
+	m := Transformation(
+		Vec3{}, IdentityQuat(), Vec3{2, 3, 4},
+		Vec3{}, q, Vec3{5, 6, 7},
+	)
+	want := Mul4(Scale(2, 3, 4), q.ToMat4(), Translate(5, 6, 7))
+	checkFloatsNear(t, m[:], want[:]...)
+
+	// The scaling is along the axes rotated by 1/8 turn about z and centered
+	// at (1,1,0).
+	m = Transformation(
+		Vec3{1, 1, 0}, QuatLeftHandZ(0.125), Vec3{2, 1, 1},
+		Vec3{}, IdentityQuat(), Vec3{},
+	)
+	checkFloatsNear(t, transformPoint(m, Vec3{1, 1, 0}), 1, 1, 0)
+	checkFloatsNear(t, transformPoint(m, Vec3{2, 2, 0}), 3, 3, 0)
+	checkFloatsNear(t, transformPoint(m, Vec3{2, 0, 5}), 2, 0, 5)
+
+	// The rotation center stays fixed and is then translated.
+	m = Transformation(
+		Vec3{}, IdentityQuat(), Vec3{1, 1, 1},
+		Vec3{1, 2, 3}, QuatLeftHandZ(0.25), Vec3{0, 0, 10},
+	)
+	checkFloatsNear(t, transformPoint(m, Vec3{1, 2, 3}), 1, 2, 13)
+	checkFloatsNear(t, transformPoint(m, Vec3{2, 2, 3}), 1, 3, 13)
+}
+
+func TestAffineTransformation(t *testing.T) {
+	q := QuatRightHandAbout(Vec3{-1, 2, 0.5}, 0.4)
+	m := AffineTransformation(3, Vec3{1, 2, 3}, q, Vec3{4, 5, 6})
+	want := Mul4(
+		ScaleUniform(3),
+		Translate(-1, -2, -3),
+		q.ToMat4(),
+		Translate(1, 2, 3),
+		Translate(4, 5, 6),
+	)
+	checkFloatsNear(t, m[:], want[:]...)
+}
+
+func TestTransformation2D(t *testing.T) {
+	m := Transformation2D(
+		Vec2{}, 0, Vec2{2, 3},
+		Vec2{}, 0.25, Vec2{5, 6},
+	)
+	// (1,1) is scaled to (2,3), rotated to (-3,2) and moved to (2,8).
+	checkFloatsNear(t, transformPoint2x3(m, Vec2{1, 1}), 2, 8)
+
+	m = Transformation2D(
+		Vec2{1, 1}, 0.125, Vec2{2, 1},
+		Vec2{}, 0, Vec2{},
+	)
+	checkFloatsNear(t, transformPoint2x3(m, Vec2{1, 1}), 1, 1)
+	checkFloatsNear(t, transformPoint2x3(m, Vec2{2, 2}), 3, 3)
+	checkFloatsNear(t, transformPoint2x3(m, Vec2{2, 0}), 2, 0)
+
+	m = Transformation2D(
+		Vec2{}, 0, Vec2{1, 1},
+		Vec2{1, 2}, 0.25, Vec2{0, 10},
+	)
+	checkFloatsNear(t, transformPoint2x3(m, Vec2{1, 2}), 1, 12)
+	checkFloatsNear(t, transformPoint2x3(m, Vec2{2, 2}), 1, 13)
+
+	// The 2D transformation matches the 3D one restricted to the xy-plane.
+	m = Transformation2D(
+		Vec2{1, -2}, 0.1, Vec2{2, 3},
+		Vec2{4, 1}, 0.3, Vec2{5, 6},
+	)
+	m3 := Transformation(
+		Vec3{1, -2, 0}, QuatLeftHandZ(0.1), Vec3{2, 3, 1},
+		Vec3{4, 1, 0}, QuatLeftHandZ(0.3), Vec3{5, 6, 0},
+	)
+	for _, v := range []Vec2{{0, 0}, {1, 0}, {0, 1}, {-3, 7}} {
+		p := transformPoint(m3, Vec3{v[0], v[1], 0})
+		checkFloatsNear(t, transformPoint2x3(m, v), p[:2]...)
+	}
+}
+
+func TestAffineTransformation2D(t *testing.T) {
+	m := AffineTransformation2D(2, Vec2{1, 1}, 0.5, Vec2{3, 0})
+	// (2,1) is scaled to (4,2), rotated about (1,1) to (-2,0) and moved to
+	// (1,0).
+	checkFloatsNear(t, transformPoint2x3(m, Vec2{2, 1}), 1, 0)
+}
+
+func transformPoint(m Mat4, v Vec3) []float32 {
+	p := v.Homogeneous().MulMat(m).ByW()
+	return p[:]
+}
+
+func transformPoint2x3(m Mat2x3, v Vec2) []float32 {
+	return []float32{
+		m[0]*v[0] + m[1]*v[1] + m[2],
+		m[3]*v[0] + m[4]*v[1] + m[5],
+	}
+}
@@ -0,0 +1,71 @@
+package d3dmath
+
+// Viewport describes the pixel area that the projected scene is mapped onto,
+// like D3DVIEWPORT9. X and Y are the top-left corner of the area, MinZ and
+// MaxZ the range that depth values are mapped onto, usually 0 and 1.
+type Viewport struct {
+	X      uint32
+	Y      uint32
+	Width  uint32
+	Height uint32
+	MinZ   float32
+	MaxZ   float32
+}
+
+// Project transforms the world-space point v by world, view and projection
+// and maps the result to screen space in the given viewport, like
+// D3DXVec3Project. The returned x and y are pixel coordinates, z is the depth
+// in the range MinZ to MaxZ.
+func Project(v Vec3, viewport Viewport, projection, view, world Mat4) Vec3 {
+	p := v.Homogeneous().MulMat(Mul4(world, view, projection)).ByW()
+	return Vec3{
+		float32(viewport.X) + (1+p[0])*float32(viewport.Width)/2,
+		float32(viewport.Y) + (1-p[1])*float32(viewport.Height)/2,
+		viewport.MinZ + p[2]*(viewport.MaxZ-viewport.MinZ),
+	}
+}
+
+// Unproject is the inverse of Project, like D3DXVec3Unproject. It maps the
+// screen-space point v, given in pixels and depth, back to world space. If the
+// combined world, view and projection matrix is singular, ok is false.
+func Unproject(v Vec3, viewport Viewport, projection, view, world Mat4) (p Vec3, ok bool) {
+	inv, ok := Mul4(world, view, projection).Inverse()
+	if !ok {
+		return Vec3{}, false
+	}
+	return unproject(v, viewport, inv), true
+}
+
+// unproject maps v from screen space back through the inverse of the combined
+// world, view and projection matrix.
+func unproject(v Vec3, viewport Viewport, inv Mat4) Vec3 {
+	depth := float32(0)
+	if viewport.MaxZ != viewport.MinZ {
+		depth = (v[2] - viewport.MinZ) / (viewport.MaxZ - viewport.MinZ)
+	}
+	p := Vec4{
+		2*(v[0]-float32(viewport.X))/float32(viewport.Width) - 1,
+		1 - 2*(v[1]-float32(viewport.Y))/float32(viewport.Height),
+		depth,
+		1,
+	}
+	return p.MulMat(inv).ByW()
+}
+
+// ScreenRay returns the world-space ray through the pixel at x, y in the given
+// viewport, e.g. for picking objects under the mouse cursor. The ray starts at
+// depth MinZ, which is the near plane for standard projections, and has a
+// Direction of length 1. For reverse-Z projections, where MinZ is the far
+// plane, the ray points towards the camera. If the combined world, view and
+// projection matrix is singular, ok is false.
+func ScreenRay(x, y float32, viewport Viewport, projection, view, world Mat4) (r Ray, ok bool) {
+	inv, ok := Mul4(world, view, projection).Inverse()
+	if !ok {
+		return Ray{}, false
+	}
+	// The second point is taken in the middle of the depth range instead of
+	// at MaxZ, which is infinitely far away for infinite projections.
+	start := unproject(Vec3{x, y, viewport.MinZ}, viewport, inv)
+	mid := unproject(Vec3{x, y, (viewport.MinZ + viewport.MaxZ) / 2}, viewport, inv)
+	return Ray{Origin: start, Direction: mid.Sub(start).Normalized()}, true
+}
@@ -0,0 +1,92 @@
+package d3dmath
+
+import "testing"
+
+func TestProjectWithIdentityMatrices(t *testing.T) {
+	viewport := Viewport{X: 10, Y: 20, Width: 200, Height: 100, MinZ: 0, MaxZ: 1}
+	id := Identity4()
+	p := Project(Vec3{0, 0, 0.5}, viewport, id, id, id)
+	checkFloats(t, p[:], 110, 70, 0.5)
+	p = Project(Vec3{1, 1, 0}, viewport, id, id, id)
+	checkFloats(t, p[:], 210, 20, 0)
+	p = Project(Vec3{-1, -1, 1}, viewport, id, id, id)
+	checkFloats(t, p[:], 10, 120, 1)
+}
+
+func TestUnprojectInvertsProject(t *testing.T) {
+	viewport := Viewport{X: 0, Y: 0, Width: 640, Height: 480, MinZ: 0, MaxZ: 1}
+	world := Mul4(RotateRightHandY(0.1), Translate(1, 0, 0))
+	view := LookAtLH(Vec3{0, 2, -5}, Vec3{0, 0, 0}, Vec3{0, 1, 0})
+	projection := PerspectiveFovLH(1, 640.0/480, 0.5, 20)
+	v := Vec3{0.5, -0.25, 0.75}
+	screen := Project(v, viewport, projection, view, world)
+	p, ok := Unproject(screen, viewport, projection, view, world)
+	if !ok {
+		t.Fatal("unproject failed")
+	}
+	checkFloatsNear(t, p[:], v[:]...)
+}
+
+func TestUnprojectSingularMatrix(t *testing.T) {
+	viewport := Viewport{Width: 640, Height: 480, MaxZ: 1}
+	id := Identity4()
+	_, ok := Unproject(Vec3{1, 2, 0}, viewport, Mat4{}, id, id)
+	if ok {
+		t.Error("singular matrix was inverted")
+	}
+	_, ok = ScreenRay(1, 2, viewport, Mat4{}, id, id)
+	if ok {
+		t.Error("singular matrix was inverted")
+	}
+}
+
+func TestScreenRayThroughViewportCenter(t *testing.T) {
+	viewport := Viewport{X: 0, Y: 0, Width: 640, Height: 480, MinZ: 0, MaxZ: 1}
+	id := Identity4()
+	eye := Vec3{0, 0, -5}
+	target := Vec3{0, 0, 0}
+	up := Vec3{0, 1, 0}
+
+	view := LookAtLH(eye, target, up)
+	projection := PerspectiveFovLH(1, 640.0/480, 0.5, 20)
+	r, ok := ScreenRay(320, 240, viewport, projection, view, id)
+	if !ok {
+		t.Fatal("screen ray failed")
+	}
+	checkFloatsNear(t, r.Origin[:], 0, 0, -4.5)
+	checkFloatsNear(t, r.Direction[:], 0, 0, 1)
+
+	view = LookAtRH(eye, target, up)
+	projection = PerspectiveFovRH(1, 640.0/480, 0.5, 20)
+	r, ok = ScreenRay(320, 240, viewport, projection, view, id)
+	if !ok {
+		t.Fatal("screen ray failed")
+	}
+	checkFloatsNear(t, r.Origin[:], 0, 0, -4.5)
+	checkFloatsNear(t, r.Direction[:], 0, 0, 1)
+
+	projection = PerspectiveFovInfiniteLH(1, 640.0/480, 0.5)
+	r, ok = ScreenRay(320, 240, viewport, projection, LookAtLH(eye, target, up), id)
+	if !ok {
+		t.Fatal("screen ray failed")
+	}
+	checkFloatsNear(t, r.Origin[:], 0, 0, -4.5)
+	checkFloatsNear(t, r.Direction[:], 0, 0, 1)
+}
+
+func TestScreenRayHitsProjectedPoint(t *testing.T) {
+	viewport := Viewport{X: 0, Y: 0, Width: 640, Height: 480, MinZ: 0, MaxZ: 1}
+	world := Translate(0.5, 0, 0)
+	view := LookAtLH(Vec3{1, 2, -5}, Vec3{0, 0, 0}, Vec3{0, 1, 0})
+	projection := PerspectiveFovLH(1, 640.0/480, 0.5, 20)
+	v := Vec3{0.3, -0.2, 0.4}
+	screen := Project(v, viewport, projection, view, world)
+	r, ok := ScreenRay(screen[0], screen[1], viewport, projection, view, world)
+	if !ok {
+		t.Fatal("screen ray failed")
+	}
+	// The ray goes through v, which is in model space since we passed world.
+	toV := v.Sub(r.Origin)
+	p := r.At(toV.Dot(r.Direction))
+	checkFloatsNear(t, p[:], v[:]...)
+}
@@ -0,0 +1,147 @@
+package d3dmath64
+
+import "github.com/gonutz/d3dmath/row_major/d3dmath"
+
+// Vec2From32 converts v to float64. This is lossless.
+func Vec2From32(v d3dmath.Vec2) (wide Vec2) {
+	for i := range v {
+		wide[i] = float64(v[i])
+	}
+	return
+}
+
+// To32 converts v to float32, rounding each element to the nearest float32.
+func (v Vec2) To32() (narrow d3dmath.Vec2) {
+	for i := range v {
+		narrow[i] = float32(v[i])
+	}
+	return
+}
+
+// Vec3From32 converts v to float64. This is lossless.
+func Vec3From32(v d3dmath.Vec3) (wide Vec3) {
+	for i := range v {
+		wide[i] = float64(v[i])
+	}
+	return
+}
+
+// To32 converts v to float32, rounding each element to the nearest float32.
+func (v Vec3) To32() (narrow d3dmath.Vec3) {
+	for i := range v {
+		narrow[i] = float32(v[i])
+	}
+	return
+}
+
+// Vec4From32 converts v to float64. This is lossless.
+func Vec4From32(v d3dmath.Vec4) (wide Vec4) {
+	for i := range v {
+		wide[i] = float64(v[i])
+	}
+	return
+}
+
+// To32 converts v to float32, rounding each element to the nearest float32.
+func (v Vec4) To32() (narrow d3dmath.Vec4) {
+	for i := range v {
+		narrow[i] = float32(v[i])
+	}
+	return
+}
+
+// Mat2From32 converts m to float64. This is lossless.
+func Mat2From32(m d3dmath.Mat2) (wide Mat2) {
+	for i := range m {
+		wide[i] = float64(m[i])
+	}
+	return
+}
+
+// To32 converts m to float32, rounding each element to the nearest float32.
+func (m Mat2) To32() (narrow d3dmath.Mat2) {
+	for i := range m {
+		narrow[i] = float32(m[i])
+	}
+	return
+}
+
+// Mat3From32 converts m to float64. This is lossless.
+func Mat3From32(m d3dmath.Mat3) (wide Mat3) {
+	for i := range m {
+		wide[i] = float64(m[i])
+	}
+	return
+}
+
+// To32 converts m to float32, rounding each element to the nearest float32.
+func (m Mat3) To32() (narrow d3dmath.Mat3) {
+	for i := range m {
+		narrow[i] = float32(m[i])
+	}
+	return
+}
+
+// Mat2x3From32 converts m to float64. This is lossless.
+func Mat2x3From32(m d3dmath.Mat2x3) (wide Mat2x3) {
+	for i := range m {
+		wide[i] = float64(m[i])
+	}
+	return
+}
+
+// To32 converts m to float32, rounding each element to the nearest float32.
+func (m Mat2x3) To32() (narrow d3dmath.Mat2x3) {
+	for i := range m {
+		narrow[i] = float32(m[i])
+	}
+	return
+}
+
+// Mat4From32 converts m to float64. This is lossless.
+func Mat4From32(m d3dmath.Mat4) (wide Mat4) {
+	for i := range m {
+		wide[i] = float64(m[i])
+	}
+	return
+}
+
+// To32 converts m to float32, rounding each element to the nearest float32.
+func (m Mat4) To32() (narrow d3dmath.Mat4) {
+	for i := range m {
+		narrow[i] = float32(m[i])
+	}
+	return
+}
+
+// QuatFrom32 converts q to float64. This is lossless.
+func QuatFrom32(q d3dmath.Quat) (wide Quat) {
+	for i := range q {
+		wide[i] = float64(q[i])
+	}
+	return
+}
+
+// To32 converts q to float32, rounding each element to the nearest float32.
+func (q Quat) To32() (narrow d3dmath.Quat) {
+	for i := range q {
+		narrow[i] = float32(q[i])
+	}
+	return
+}
+
+// PlaneFrom32 converts p to float64. This is lossless.
+func PlaneFrom32(p d3dmath.Plane) (wide Plane) {
+	for i := range p {
+		wide[i] = float64(p[i])
+	}
+	return
+}
+
+// To32 converts p to float32, rounding each element to the nearest float32.
+func (p Plane) To32() (narrow d3dmath.Plane) {
+	for i := range p {
+		narrow[i] = float32(p[i])
+	}
+	return
+}
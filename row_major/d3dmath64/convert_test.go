@@ -0,0 +1,49 @@
+package d3dmath64
+
+import (
+	"testing"
+
+	"github.com/gonutz/d3dmath/row_major/d3dmath"
+)
+
+func TestFrom32IsLossless(t *testing.T) {
+	v := d3dmath.Vec3{0.1, 1e-30, 123456.79}
+	w := Vec3From32(v)
+	checkFloats(t, w[:], float64(v[0]), float64(v[1]), float64(v[2]))
+	if w.To32() != v {
+		t.Errorf("round trip changed %v to %v", v, w.To32())
+	}
+
+	m := d3dmath.RotateRightHandAbout(d3dmath.Vec3{1, 2, 3}, 0.1)
+	if Mat4From32(m).To32() != m {
+		t.Error("matrix changed in round trip")
+	}
+	m2x3 := d3dmath.Mat2x3{1, 2, 3, 4, 5, 6}
+	if Mat2x3From32(m2x3).To32() != m2x3 {
+		t.Error("2x3 matrix changed in round trip")
+	}
+}
+
+func TestTo32RoundsToNearest(t *testing.T) {
+	v := Vec4{0.1, 1.0 / 3, 1e8 + 1, -2}.To32()
+	want := d3dmath.Vec4{0.1, 1.0 / 3, 1e8, -2}
+	if v != want {
+		t.Errorf("have %v but want %v", v, want)
+	}
+	q := QuatLeftHandY(0.1).To32()
+	want32 := d3dmath.QuatLeftHandY(0.1)
+	for i := range q {
+		if d := q[i] - want32[i]; d > 1e-7 || d < -1e-7 {
+			t.Errorf("have %v but want %v", q, want32)
+		}
+	}
+}
+
+func TestDoublePrecisionKeepsSmallOffsets(t *testing.T) {
+	// At 1e8, float32 cannot represent offsets smaller than 8. Moving a point
+	// far from the origin relative to the camera must keep its fraction.
+	p := Vec3{1e8 + 0.25, 3, 0}.Homogeneous().MulMat(Translate(-1e8, 0, 0))
+	if v := p.ByW().To32(); v != (d3dmath.Vec3{0.25, 3, 0}) {
+		t.Errorf("have %v but want (0.25 3 0)", v)
+	}
+}
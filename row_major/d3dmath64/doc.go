@@ -0,0 +1,10 @@
+/*
+Package d3dmath64 is the float64 version of package d3dmath. Vectors are row
+vectors and matrices are stored in row-major order.
+
+Use it for computations that need double precision, like large world
+coordinates, and convert the results to float32 with the To32 methods before
+passing them to Direct3D. The From32 functions widen float32 values without
+loss.
+*/
+package d3dmath64
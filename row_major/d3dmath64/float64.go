@@ -0,0 +1,22 @@
+package d3dmath64
+
+import "math"
+
+// This file holds the code that depends on the float type. It replaces
+// float32.go of package row_major/d3dmath, see internal/gen.
+
+// ulpKey maps the bits of f to an integer that is monotonic in f, so the
+// difference of two keys is their distance in ULPs.
+func ulpKey(f float64) uint64 {
+	const signBit = 1 << 63
+	bits := math.Float64bits(f)
+	if bits&signBit != 0 {
+		return signBit - (bits &^ signBit)
+	}
+	return signBit + bits
+}
+
+// epsilon returns the difference between 1 and the next larger float64.
+func epsilon() float64 {
+	return 1.0 / (1 << 52)
+}
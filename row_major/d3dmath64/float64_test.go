@@ -0,0 +1,6 @@
+package d3dmath64
+
+// nearTolerance returns the absolute tolerance of checkFloatsNear.
+func nearTolerance() float64 {
+	return 1e-9
+}
@@ -0,0 +1,10 @@
+package d3dmath64
+
+import "testing"
+
+// benchmarkSIMD runs f. There is no SIMD code for float64, the function
+// exists because the benchmarks are generated from package d3dmath, which
+// runs them with and without SIMD.
+func benchmarkSIMD(b *testing.B, f func(b *testing.B)) {
+	b.Run("go", f)
+}
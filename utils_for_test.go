@@ -1,6 +1,10 @@
 package d3dmath
 
-import "testing"
+import (
+	"testing"
+
+	rowmajor "github.com/gonutz/d3dmath/row_major/d3dmath"
+)
 
 func checkString(t *testing.T, have, want string) {
 	if have != want {
@@ -8,8 +12,11 @@ func checkString(t *testing.T, have, want string) {
 	}
 }
 
+// checkFloat and checkFloats allow a few ULPs of difference because the
+// compiler may fuse multiplications and additions on some platforms, which
+// changes the last bits of a result.
 func checkFloat(t *testing.T, have, want float32) {
-	if have != want {
+	if rowmajor.ULPDistance(have, want) > 4 {
 		t.Errorf("float differs, have %f but want %f", have, want)
 	}
 }
@@ -18,7 +25,22 @@ func checkFloats(t *testing.T, have []float32, want ...float32) {
 	eq := len(have) == len(want)
 	if eq {
 		for i := range have {
-			if have[i] != want[i] {
+			if rowmajor.ULPDistance(have[i], want[i]) > 4 {
+				eq = false
+			}
+		}
+	}
+	if !eq {
+		t.Errorf("floats differ, have\n%v\nbut want\n%v", have, want)
+	}
+}
+
+func checkFloatsNear(t *testing.T, have []float32, want ...float32) {
+	const epsilon = 1e-5
+	eq := len(have) == len(want)
+	if eq {
+		for i := range have {
+			if !rowmajor.ApproxEqual(have[i], want[i], epsilon) {
 				eq = false
 			}
 		}